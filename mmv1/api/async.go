@@ -14,9 +14,9 @@
 package api
 
 import (
-	"log"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
@@ -129,14 +129,16 @@ func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (a *Async) Validate() {
+func (a *Async) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
-			log.Fatalf("Missing `Operation` for OpAsync")
+			errs.Addf("operation", "Missing `Operation` for OpAsync")
 		} else {
 			if a.Operation.BaseUrl != "" && a.Operation.FullUrl != "" {
-				log.Fatalf("`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+				errs.Addf("operation", "`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
 			}
 		}
 	}
+	return errs
 }
@@ -15,22 +15,29 @@ package api
 
 import (
 	"bytes"
-	"log"
+	"fmt"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}, overrideDir string) {
+// Loads the YAML file at yamlPath into obj. Any problems are returned as
+// google.ValidationErrors.
+func Compile(yamlPath string, obj interface{}, overrideDir string) error {
 	objYaml, err := os.ReadFile(yamlPath)
 	if err != nil {
-		log.Fatalf("Cannot open the file: %v", objYaml)
+		return google.ValidationErrors{{File: yamlPath, Message: fmt.Sprintf("cannot open the file: %v", err)}}
 	}
 
 	if overrideDir != "" {
 		objYaml = bytes.ReplaceAll(objYaml, []byte("{{override_path}}"), []byte(overrideDir))
 	}
 
+	objYaml, err = expandFragments(objYaml, yamlPath)
+	if err != nil {
+		return err
+	}
+
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	return yamlValidator.Parse(objYaml, obj, yamlPath)
 }
@@ -0,0 +1,194 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// An ephemeral resource that calls a single API method, such as
+// `:generateAccessToken` or `:access`, and exposes fields of the response.
+// Ephemeral resources are never stored in state, which makes them suitable
+// for secrets. They are generated as plugin-framework ephemeral resources.
+type EphemeralResource struct {
+	// The Terraform name, e.g. google_secret_manager_secret_version_access
+	Name string `yaml:"name"`
+
+	Description string `yaml:"description"`
+
+	// The URL of the method, relative to the product's base URL, e.g.
+	// `{{name}}:access`. Variables are filled in from the arguments of the
+	// same name, e.g. `{{secret_id}}` from `secretId`. The
+	// project, region and zone fall back to the provider configuration, and
+	// are added as optional arguments if they are not declared.
+	Url string `yaml:"url"`
+
+	// The HTTP verb of the method, GET or POST. Defaults to GET.
+	Verb string `yaml:"verb,omitempty"`
+
+	// The values set in configuration. Arguments that are not used in the
+	// URL are sent as query parameters for GET, and as fields of the request
+	// body for POST. Only String, Enum, Integer and Boolean are supported.
+	Arguments []*Type `yaml:"arguments,omitempty"`
+
+	// The values read from the response. `api_name` can be a dot separated
+	// path, e.g. `payload.data`. String, Enum, Time, Integer, Double,
+	// Boolean, arrays of strings and KeyValuePairs are supported.
+	Attributes []*Type `yaml:"attributes,omitempty"`
+
+	// HCL expressions for the arguments in the generated acceptance test, by
+	// argument name. By default a required argument references the
+	// attribute of the same name of the resource created by the test example.
+	TestArguments map[string]string `yaml:"test_arguments,omitempty"`
+
+	ResourceMetadata *Resource `yaml:"-"`
+}
+
+func (e *EphemeralResource) SetDefault(r *Resource) {
+	e.ResourceMetadata = r
+	if e.Verb == "" {
+		e.Verb = "GET"
+	}
+	for _, v := range []string{"project", "region", "zone"} {
+		if slices.Contains(e.UrlVariables(), v) && e.argument(v) == nil {
+			e.Arguments = append(e.Arguments, &Type{
+				Name:        v,
+				Type:        "String",
+				Description: fmt.Sprintf("The %s of the %s. If it is not provided, the provider %s is used.", v, google.SpaceSeparated(r.Name), v),
+			})
+		}
+	}
+	for _, t := range append(slices.Clone(e.Arguments), e.Attributes...) {
+		t.SetDefault(r)
+	}
+}
+
+func (e *EphemeralResource) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if !strings.HasPrefix(e.Name, "google_") {
+		errs.Addf("name", "Ephemeral resource name %q in resource %s must start with google_", e.Name, rName)
+	}
+	if e.Description == "" {
+		errs.Addf("description", "Missing `description` for ephemeral resource %s", e.Name)
+	}
+	if e.Url == "" {
+		errs.Addf("url", "Missing `url` for ephemeral resource %s", e.Name)
+	}
+	if !slices.Contains([]string{"GET", "POST"}, e.Verb) {
+		errs.Addf("verb", "Value on `verb` should be one of %#v", []string{"GET", "POST"})
+	}
+
+	for _, v := range e.UrlVariables() {
+		if e.argument(v) == nil {
+			errs.Addf("url", "Missing argument for URL variable %s in ephemeral resource %s", v, e.Name)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, a := range e.Arguments {
+		path := fmt.Sprintf("arguments[%s]", a.Name)
+		if !slices.Contains([]string{"String", "Enum", "Integer", "Boolean"}, a.Type) {
+			errs.Addf(path, "Argument %s of ephemeral resource %s has unsupported type %s", a.Name, e.Name, a.Type)
+		}
+		names[a.Name] = true
+	}
+	for _, a := range e.Attributes {
+		path := fmt.Sprintf("attributes[%s]", a.Name)
+		if names[a.Name] {
+			errs.Addf(path, "Attribute %s of ephemeral resource %s has the same name as an argument", a.Name, e.Name)
+		}
+		if e.FrameworkType(a) == "" {
+			errs.Addf(path, "Attribute %s of ephemeral resource %s has unsupported type %s", a.Name, e.Name, a.Type)
+		}
+	}
+	for name := range e.TestArguments {
+		if e.argument(name) == nil {
+			errs.Addf(fmt.Sprintf("test_arguments.%s", name), "Unknown argument %s in ephemeral resource %s", name, e.Name)
+		}
+	}
+	return errs
+}
+
+// Returns the Go name of the ephemeral resource, e.g.
+// SecretManagerSecretVersionAccess.
+func (e EphemeralResource) GoName() string {
+	return google.Camelize(strings.TrimPrefix(e.Name, "google_"), "upper")
+}
+
+func (e EphemeralResource) GetDescription() string {
+	return strings.TrimSpace(e.Description)
+}
+
+// Returns the variables of the URL, in order.
+func (e EphemeralResource) UrlVariables() []string {
+	var vars []string
+	for _, m := range regexp.MustCompile(`{{%?(\w+)}}`).FindAllStringSubmatch(e.Url, -1) {
+		if !slices.Contains(vars, m[1]) {
+			vars = append(vars, m[1])
+		}
+	}
+	return vars
+}
+
+// Returns the arguments that are not used in the URL.
+func (e EphemeralResource) RequestArguments() []*Type {
+	return google.Reject(e.Arguments, func(a *Type) bool {
+		return slices.Contains(e.UrlVariables(), google.Underscore(a.Name))
+	})
+}
+
+// Returns the HCL expression of an argument in the generated acceptance
+// test, or "" if the argument is left unset.
+func (e EphemeralResource) TestArgument(a *Type, resourceAddress string) string {
+	if v, ok := e.TestArguments[a.Name]; ok {
+		return v
+	}
+	if !a.Required {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s", resourceAddress, google.Underscore(a.Name))
+}
+
+// Returns the plugin framework schema attribute type of a property.
+func (e EphemeralResource) FrameworkAttribute(t *Type) string {
+	if e.FrameworkType(t) == "" {
+		return ""
+	}
+	return t.FrameworkAttribute()
+}
+
+// Returns the plugin framework value type of a property, or "" if the type
+// is not supported. Unlike resources, ephemeral resources have no nested
+// attributes.
+func (e EphemeralResource) FrameworkType(t *Type) string {
+	if t.Type == "NestedObject" || (t.Type == "Array" && t.ItemType != nil && t.ItemType.Type == "NestedObject") {
+		return ""
+	}
+	return t.FrameworkType()
+}
+
+// Returns the argument with the given name, in either camel or snake case.
+func (e EphemeralResource) argument(name string) *Type {
+	for _, a := range e.Arguments {
+		if google.Underscore(a.Name) == google.Underscore(name) {
+			return a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEphemeralResourceSetDefault(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		Name:            "Secret",
+		ProductMetadata: &Product{Name: "SecretManager"},
+		EphemeralResources: []*EphemeralResource{
+			{
+				Description: "Accesses a secret.",
+				Url:         "projects/{{project}}/secrets/{{secret_id}}:access",
+				Arguments: []*Type{
+					{Name: "secretId", Type: "String", Required: true},
+				},
+				Attributes: []*Type{
+					{Name: "secretData", ApiName: "payload.data", Type: "String"},
+				},
+			},
+		},
+	}
+	r.SetDefault(r.ProductMetadata)
+
+	e := r.EphemeralResources[0]
+	if got, want := e.Name, "google_secret_manager_secret"; got != want {
+		t.Errorf("expected name %s, got %s", want, got)
+	}
+	if got, want := e.Verb, "GET"; got != want {
+		t.Errorf("expected verb %s, got %s", want, got)
+	}
+	if got, want := e.GoName(), "SecretManagerSecret"; got != want {
+		t.Errorf("expected Go name %s, got %s", want, got)
+	}
+	var names []string
+	for _, a := range e.Arguments {
+		names = append(names, a.Name)
+	}
+	if want := []string{"secretId", "project"}; !slices.Equal(names, want) {
+		t.Errorf("expected arguments %v, got %v", want, names)
+	}
+	if got := e.RequestArguments(); len(got) != 0 {
+		t.Errorf("expected no request arguments, got %d", len(got))
+	}
+	if got, want := e.Attributes[0].ApiName, "payload.data"; got != want {
+		t.Errorf("expected api_name %s, got %s", want, got)
+	}
+	if errs := e.Validate(r.Name); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestEphemeralResourceValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         EphemeralResource
+		wantPaths   []string
+	}{
+		{
+			description: "valid",
+			obj: EphemeralResource{
+				Name:        "google_secret_manager_secret_version_access",
+				Description: "Accesses a secret version.",
+				Url:         "{{name}}:access",
+				Verb:        "GET",
+				Arguments:   []*Type{{Name: "name", Type: "String", Required: true}},
+				Attributes: []*Type{
+					{Name: "secretData", Type: "String"},
+					{Name: "aliases", Type: "Array", ItemType: &Type{Type: "String"}},
+					{Name: "labels", Type: "KeyValuePairs"},
+				},
+			},
+		},
+		{
+			description: "missing fields",
+			obj:         EphemeralResource{Name: "secret", Verb: "PUT"},
+			wantPaths:   []string{"name", "description", "url", "verb"},
+		},
+		{
+			description: "missing URL argument",
+			obj: EphemeralResource{
+				Name:        "google_secret_manager_secret_version_access",
+				Description: "Accesses a secret version.",
+				Url:         "{{name}}:access",
+				Verb:        "GET",
+				TestArguments: map[string]string{
+					"missing": "\"value\"",
+				},
+			},
+			wantPaths: []string{"url", "test_arguments.missing"},
+		},
+		{
+			description: "unsupported types",
+			obj: EphemeralResource{
+				Name:        "google_secret_manager_secret_version_access",
+				Description: "Accesses a secret version.",
+				Url:         "{{name}}:access",
+				Verb:        "POST",
+				Arguments: []*Type{
+					{Name: "name", Type: "String", Required: true},
+					{Name: "options", Type: "NestedObject"},
+				},
+				Attributes: []*Type{
+					{Name: "name", Type: "String"},
+					{Name: "versions", Type: "Array", ItemType: &Type{Type: "NestedObject"}},
+				},
+			},
+			wantPaths: []string{"arguments[options]", "attributes[name]", "attributes[versions]"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var paths []string
+			for _, e := range tc.obj.Validate("Secret") {
+				paths = append(paths, e.Path)
+			}
+			if !slices.Equal(paths, tc.wantPaths) {
+				t.Errorf("expected errors at %v, got %v", tc.wantPaths, paths)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// The key of a `properties` or `parameters` entry that is replaced by the
+// fields of a fragment, for example `- include: 'kms_key_name'`.
+const fragmentIncludeKey = "include"
+
+// The directory fragments are defined in, next to the resource YAML for
+// fragments of a product and next to the products directory for fragments
+// shared by every product.
+const fragmentDir = "fragments"
+
+// The keys whose entries are fields and may include fragments.
+var fragmentListKeys = []string{"properties", "parameters"}
+
+// Returns the directories searched for the fragments included by the YAML
+// file at yamlPath, in order: the fragments of its product, then the shared
+// fragments. For `products/pubsub/Topic.yaml` they are
+// `products/pubsub/fragments` and `fragments`.
+func FragmentDirs(yamlPath string) []string {
+	productDir := filepath.Dir(yamlPath)
+	return []string{
+		filepath.Join(productDir, fragmentDir),
+		filepath.Join(productDir, "..", "..", fragmentDir),
+	}
+}
+
+// Replaces every entry of the form `- include: 'name'` in a list of
+// properties or parameters with the fields of the fragment `name.yaml`. A
+// fragment is a YAML list of fields, in the same format as `properties`, and
+// may itself include other fragments. Keys set next to `include` override the
+// keys of the included field, and are only allowed for fragments with a single
+// field.
+//
+// content is returned unchanged if it does not include any fragment, so that
+// problems found later are reported at their original position.
+func expandFragments(content []byte, yamlPath string) ([]byte, error) {
+	if !bytes.Contains(content, []byte(fragmentIncludeKey+":")) {
+		return content, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		// Reported with its position when the content is parsed
+		return content, nil
+	}
+
+	e := fragmentExpander{
+		yamlPath:  yamlPath,
+		dirs:      FragmentDirs(yamlPath),
+		fragments: map[string]loadedFragment{},
+	}
+	if err := e.expand(&root, nil); err != nil {
+		return nil, err
+	}
+	if !e.expanded {
+		return content, nil
+	}
+
+	quoteLeadingBlankLines(&root)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, google.ValidationErrors{{File: yamlPath, Message: fmt.Sprintf("cannot expand fragments: %v", err)}}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, google.ValidationErrors{{File: yamlPath, Message: fmt.Sprintf("cannot expand fragments: %v", err)}}
+	}
+	return buf.Bytes(), nil
+}
+
+type loadedFragment struct {
+	path   string
+	fields *yaml.Node
+}
+
+type fragmentExpander struct {
+	yamlPath string
+	dirs     []string
+
+	// Loaded fragments by name
+	fragments map[string]loadedFragment
+
+	// Whether any fragment was included
+	expanded bool
+}
+
+// Expands the includes of every list of fields under n. stack holds the
+// fragments being expanded, to detect fragments that include themselves.
+func (e *fragmentExpander) expand(n *yaml.Node, stack []string) error {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range n.Content {
+			if err := e.expand(child, stack); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if value.Kind == yaml.SequenceNode && slices.Contains(fragmentListKeys, key.Value) {
+				if err := e.expandFields(value, stack); err != nil {
+					return err
+				}
+			}
+			if err := e.expand(value, stack); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Replaces the include entries of a list of fields with the fields of their
+// fragments.
+func (e *fragmentExpander) expandFields(list *yaml.Node, stack []string) error {
+	var fields []*yaml.Node
+	for _, entry := range list.Content {
+		name, overrides, ok := includeEntry(entry)
+		if !ok {
+			fields = append(fields, entry)
+			continue
+		}
+		if name == "" {
+			return e.errorf(entry, "%s must be the name of a fragment", fragmentIncludeKey)
+		}
+		if slices.Contains(stack, name) {
+			return e.errorf(entry, "fragment %q includes itself: %s", name, strings.Join(append(stack, name), " -> "))
+		}
+
+		loaded, err := e.load(name)
+		if err != nil {
+			return e.errorf(entry, "%v", err)
+		}
+		// Problems within the fragment are reported in its own file
+		inFragment := *e
+		inFragment.yamlPath = loaded.path
+		fragment := copyNode(loaded.fields)
+		if err := inFragment.expand(fragment, append(stack, name)); err != nil {
+			return err
+		}
+		if len(overrides) > 0 {
+			if len(fragment.Content) != 1 {
+				return e.errorf(entry, "fragment %q defines %d fields, keys can only be overridden for fragments with a single field", name, len(fragment.Content))
+			}
+			overrideKeys(fragment.Content[0], overrides)
+		}
+		fields = append(fields, fragment.Content...)
+		e.expanded = true
+	}
+	list.Content = fields
+	return nil
+}
+
+// Returns the fields of the fragment name, as a sequence node.
+func (e *fragmentExpander) load(name string) (loadedFragment, error) {
+	if f, ok := e.fragments[name]; ok {
+		return f, nil
+	}
+	for _, dir := range e.dirs {
+		path := filepath.Join(dir, name+".yaml")
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return loadedFragment{}, fmt.Errorf("cannot read fragment %q: %v", name, err)
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(content, &root); err != nil {
+			return loadedFragment{}, fmt.Errorf("cannot parse fragment %s: %v", path, err)
+		}
+		if root.Kind != yaml.DocumentNode || len(root.Content) != 1 || root.Content[0].Kind != yaml.SequenceNode {
+			return loadedFragment{}, fmt.Errorf("fragment %s must be a list of fields", path)
+		}
+		e.fragments[name] = loadedFragment{path: path, fields: root.Content[0]}
+		return e.fragments[name], nil
+	}
+	return loadedFragment{}, fmt.Errorf("fragment %q not found in %s", name, strings.Join(e.dirs, ", "))
+}
+
+func (e *fragmentExpander) errorf(n *yaml.Node, format string, a ...any) error {
+	return google.ValidationErrors{{
+		File:    e.yamlPath,
+		Line:    n.Line,
+		Column:  n.Column,
+		Message: fmt.Sprintf(format, a...),
+	}}
+}
+
+// Returns the fragment name and the other key and value nodes of an include
+// entry, and false if entry is not an include entry.
+func includeEntry(entry *yaml.Node) (string, []*yaml.Node, bool) {
+	if entry.Kind != yaml.MappingNode {
+		return "", nil, false
+	}
+	var name string
+	var found bool
+	var overrides []*yaml.Node
+	for i := 0; i+1 < len(entry.Content); i += 2 {
+		key, value := entry.Content[i], entry.Content[i+1]
+		if key.Value == fragmentIncludeKey {
+			found = true
+			if value.Kind == yaml.ScalarNode {
+				name = value.Value
+			}
+			continue
+		}
+		overrides = append(overrides, key, value)
+	}
+	return name, overrides, found
+}
+
+// Sets the key and value pairs of overrides in the mapping node field,
+// replacing the values of keys it already has.
+func overrideKeys(field *yaml.Node, overrides []*yaml.Node) {
+	for i := 0; i+1 < len(overrides); i += 2 {
+		key, value := overrides[i], overrides[i+1]
+		replaced := false
+		for j := 0; j+1 < len(field.Content); j += 2 {
+			if field.Content[j].Value == key.Value {
+				field.Content[j+1] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			field.Content = append(field.Content, key, value)
+		}
+	}
+}
+
+// The encoder drops the leading blank lines of block scalars, so they are
+// written quoted instead.
+func quoteLeadingBlankLines(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 && strings.HasPrefix(n.Value, "\n") {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range n.Content {
+		quoteLeadingBlankLines(child)
+	}
+}
+
+func copyNode(n *yaml.Node) *yaml.Node {
+	c := *n
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
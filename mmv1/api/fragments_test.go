@@ -0,0 +1,237 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Writes files, by path relative to a temporary directory, and returns the
+// directory.
+func writeFragmentFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCompileFragments(t *testing.T) {
+	t.Parallel()
+
+	fragments := map[string]string{
+		"fragments/timestamps.yaml": `- name: 'createTime'
+  type: String
+  description: 'The time the resource was created.'
+  output: true
+- name: 'updateTime'
+  type: String
+  description: 'The time the resource was last updated.'
+  output: true
+`,
+		"products/pubsub/fragments/kms_key_name.yaml": `- name: 'kmsKeyName'
+  type: String
+  description: |
+    The resource name of the Cloud KMS CryptoKey.
+  immutable: true
+`,
+		"products/pubsub/fragments/encryption.yaml": `- name: 'encryptionConfig'
+  type: NestedObject
+  properties:
+    - include: 'kms_key_name'
+`,
+	}
+
+	cases := []struct {
+		description string
+		withInclude string
+		expanded    string
+	}{
+		{
+			description: "shared fragment with several fields",
+			withInclude: `name: 'Topic'
+properties:
+  - name: 'name'
+    type: String
+  - include: 'timestamps'
+`,
+			expanded: `name: 'Topic'
+properties:
+  - name: 'name'
+    type: String
+  - name: 'createTime'
+    type: String
+    description: 'The time the resource was created.'
+    output: true
+  - name: 'updateTime'
+    type: String
+    description: 'The time the resource was last updated.'
+    output: true
+`,
+		},
+		{
+			description: "product fragment with overridden keys",
+			withInclude: `name: 'Topic'
+parameters:
+  - include: 'kms_key_name'
+    immutable: false
+    required: true
+`,
+			expanded: `name: 'Topic'
+parameters:
+  - name: 'kmsKeyName'
+    type: String
+    description: |
+      The resource name of the Cloud KMS CryptoKey.
+    immutable: false
+    required: true
+`,
+		},
+		{
+			description: "fragment including another fragment",
+			withInclude: `name: 'Topic'
+properties:
+  - include: 'encryption'
+`,
+			expanded: `name: 'Topic'
+properties:
+  - name: 'encryptionConfig'
+    type: NestedObject
+    properties:
+      - name: 'kmsKeyName'
+        type: String
+        description: |
+          The resource name of the Cloud KMS CryptoKey.
+        immutable: true
+`,
+		},
+		{
+			description: "block scalar with a leading blank line",
+			withInclude: "name: 'Topic'\ndescription: |2-\n\n  Possible values.\nproperties:\n  - include: 'timestamps'\n",
+			expanded: `name: 'Topic'
+description: "\nPossible values."
+properties:
+  - name: 'createTime'
+    type: String
+    description: 'The time the resource was created.'
+    output: true
+  - name: 'updateTime'
+    type: String
+    description: 'The time the resource was last updated.'
+    output: true
+`,
+		},
+		{
+			description: "fragment in nested properties",
+			withInclude: `name: 'Topic'
+properties:
+  - name: 'config'
+    type: Array
+    item_type:
+      type: NestedObject
+      properties:
+        - include: 'kms_key_name'
+          name: 'key'
+`,
+			expanded: `name: 'Topic'
+properties:
+  - name: 'config'
+    type: Array
+    item_type:
+      type: NestedObject
+      properties:
+        - name: 'key'
+          type: String
+          description: |
+            The resource name of the Cloud KMS CryptoKey.
+          immutable: true
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			files := map[string]string{
+				"products/pubsub/Topic.yaml":    tc.withInclude,
+				"products/pubsub/Expanded.yaml": tc.expanded,
+			}
+			for k, v := range fragments {
+				files[k] = v
+			}
+			dir := writeFragmentFiles(t, files)
+
+			got := &Resource{}
+			if err := Compile(filepath.Join(dir, "products/pubsub/Topic.yaml"), got, ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := &Resource{}
+			if err := Compile(filepath.Join(dir, "products/pubsub/Expanded.yaml"), expected, ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected %+v, got %+v", expected, got)
+			}
+		})
+	}
+}
+
+func TestCompileFragmentsErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		files       map[string]string
+		expected    string
+	}{
+		{
+			description: "missing fragment",
+			files: map[string]string{
+				"products/pubsub/Topic.yaml": "name: 'Topic'\nproperties:\n  - include: 'labels'\n",
+			},
+			expected: `Topic.yaml:3:5: fragment "labels" not found`,
+		},
+		{
+			description: "fragment including itself",
+			files: map[string]string{
+				"products/pubsub/Topic.yaml":          "name: 'Topic'\nproperties:\n  - include: 'loop'\n",
+				"products/pubsub/fragments/loop.yaml": "- name: 'config'\n  type: NestedObject\n  properties:\n    - include: 'loop'\n",
+			},
+			expected: `loop.yaml:4:7: fragment "loop" includes itself: loop -> loop`,
+		},
+		{
+			description: "overridden keys of a fragment with several fields",
+			files: map[string]string{
+				"products/pubsub/Topic.yaml":           "name: 'Topic'\nproperties:\n  - include: 'times'\n    output: false\n",
+				"products/pubsub/fragments/times.yaml": "- name: 'createTime'\n  type: String\n- name: 'updateTime'\n  type: String\n",
+			},
+			expected: `Topic.yaml:3:5: fragment "times" defines 2 fields, keys can only be overridden for fragments with a single field`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writeFragmentFiles(t, tc.files)
+			err := Compile(filepath.Join(dir, "products/pubsub/Topic.yaml"), &Resource{}, "")
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -91,15 +91,16 @@ func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+func (p *Product) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		errs.Addf("name", "Missing `name` for product")
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			errs.Addf("name", "product name `%s` must start with a capital letter.", p.Name)
 		}
 		if i == 0 {
 			break
@@ -107,20 +108,22 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		errs.Addf("scopes", "Missing `scopes` for product %s", p.Name)
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		errs.Addf("versions", "Missing `versions` for product %s", p.Name)
 	}
 
-	for _, v := range p.Versions {
-		v.Validate(p.Name)
+	for i, v := range p.Versions {
+		errs.Nest(fmt.Sprintf("versions[%d]", i), v.Validate(p.Name))
 	}
 
 	if p.Async != nil {
-		p.Async.Validate()
+		errs.Nest("async", p.Async.Validate())
 	}
+
+	return errs
 }
 
 // ====================
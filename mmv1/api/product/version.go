@@ -14,8 +14,7 @@
 package product
 
 import (
-	"log"
-
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
@@ -32,13 +31,15 @@ type Version struct {
 	Name             string
 }
 
-func (v *Version) Validate(pName string) {
+func (v *Version) Validate(pName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	if v.Name == "" {
-		log.Fatalf("Missing `name` in `version` for product %s", pName)
+		errs.Addf("name", "Missing `name` in `version` for product %s", pName)
 	}
 	if v.BaseUrl == "" {
-		log.Fatalf("Missing `base_url` in `version` for product %s", pName)
+		errs.Addf("base_url", "Missing `base_url` in `version` for product %s", pName)
 	}
+	return errs
 }
 
 func (v *Version) CompareTo(other *Version) int {
@@ -24,8 +24,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/golang/glog"
-
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
@@ -146,6 +144,11 @@ type Resource struct {
 	// the decoder will be included within the code handling the nested query.
 	NestedQuery *resource.NestedQuery `yaml:"nested_query,omitempty"`
 
+	// [Optional] (Api::Resource::Batching) Declares create, update or delete
+	// requests that can be combined with the same requests of other resources
+	// and sent to a batch endpoint.
+	Batching *resource.Batching `yaml:"batching,omitempty"`
+
 	// ====================
 	// IAM Configuration
 	// ====================
@@ -229,6 +232,22 @@ type Resource struct {
 	// If true, resource should be autogenerated as a data source
 	GenerateDatasource bool `yaml:"generate_datasource,omitempty"`
 
+	// If set, a plural data source listing the resource is generated
+	ListDatasource *resource.ListDatasource `yaml:"list_datasource,omitempty"`
+
+	// Overrides the generation of the list resource, see
+	// ShouldGenerateListResource
+	ListResource *resource.ListResource `yaml:"list_resource,omitempty"`
+
+	// Ephemeral resources that call a method of the resource, see
+	// EphemeralResource
+	EphemeralResources []*EphemeralResource `yaml:"ephemeral_resources,omitempty"`
+
+	// If true, the resource is generated as a plugin-framework resource
+	// instead of an SDKv2 resource. Only a subset of the generator's features
+	// is supported, see validatePluginFramework.
+	PluginFramework bool `yaml:"plugin_framework,omitempty"`
+
 	// If true, skip sweeper generation for this resource
 	ExcludeSweeper bool `yaml:"exclude_sweeper,omitempty"`
 
@@ -302,7 +321,7 @@ type Resource struct {
 	// Add a deprecation message for a resource that's been deprecated in the API.
 	DeprecationMessage string `yaml:"deprecation_message,omitempty"`
 
-	Async *Async
+	Async *Async `yaml:"async,omitempty"`
 
 	// Tag autogen resources so that we can track them. In the future this will
 	// control if a resource is continuously generated from public OpenAPI docs
@@ -356,6 +375,9 @@ type Resource struct {
 	ImportPath     string `yaml:"-"`
 	SourceYamlFile string `yaml:"-"`
 
+	// Path of the override YAML merged into this resource, if any
+	OverrideYamlFile string `yaml:"-"`
+
 	// ====================
 	// TGC
 	// ====================
@@ -440,77 +462,113 @@ func (r *Resource) SetDefault(product *Product) {
 	if r.Timeouts == nil {
 		r.Timeouts = NewTimeouts()
 	}
+	if r.Batching != nil {
+		r.Batching.SetDefault()
+	}
+	for _, e := range r.EphemeralResources {
+		if e.Name == "" {
+			e.Name = r.TerraformName()
+		}
+		e.SetDefault(r)
+	}
 
 }
 
-func (r *Resource) Validate() {
+func (r *Resource) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if r.Name == "" {
-		log.Fatalf("Missing `name` for resource")
+		errs.Addf("name", "Missing `name` for resource")
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		log.Fatalf("`is_list_of_ids: true` implies resource has exactly one `identity` property")
+		errs.Addf("nested_query.is_list_of_ids", "`is_list_of_ids: true` implies resource has exactly one `identity` property")
 	}
 
 	// Ensures we have all properties defined
-	for _, i := range r.Identity {
+	for idx, i := range r.Identity {
 		hasIdentify := slices.ContainsFunc(r.AllUserProperties(), func(p *Type) bool {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			log.Fatalf("Missing property/parameter for identity %s", i)
+			errs.Addf(fmt.Sprintf("identity[%d]", idx), "Missing property/parameter for identity %s", i)
 		}
 	}
 
 	if r.Description == "" {
-		log.Fatalf("Missing `description` for resource %s", r.Name)
+		errs.Addf("description", "Missing `description` for resource %s", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			log.Fatalf("Missing `properties` for resource %s", r.Name)
+			errs.Addf("properties", "Missing `properties` for resource %s", r.Name)
 		}
 	}
 
 	allowed := []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.CreateVerb) {
-		log.Fatalf("Value on `create_verb` should be one of %#v", allowed)
+		errs.Addf("create_verb", "Value on `create_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"GET", "POST"}
 	if !slices.Contains(allowed, r.ReadVerb) {
-		log.Fatalf("Value on `read_verb` should be one of %#v", allowed)
+		errs.Addf("read_verb", "Value on `read_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
 	if !slices.Contains(allowed, r.DeleteVerb) {
-		log.Fatalf("Value on `delete_verb` should be one of %#v", allowed)
+		errs.Addf("delete_verb", "Value on `delete_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.UpdateVerb) {
-		log.Fatalf("Value on `update_verb` should be one of %#v", allowed)
+		errs.Addf("update_verb", "Value on `update_verb` should be one of %#v", allowed)
 	}
 
-	for _, property := range r.AllProperties() {
-		property.Validate(r.Name)
+	for _, property := range r.Properties {
+		errs.Nest(fmt.Sprintf("properties[%s]", property.Name), property.Validate(r.Name))
+	}
+	for _, parameter := range r.Parameters {
+		errs.Nest(fmt.Sprintf("parameters[%s]", parameter.Name), parameter.Validate(r.Name))
 	}
 
 	if r.IamPolicy != nil {
-		r.IamPolicy.Validate(r.Name)
+		errs.Nest("iam_policy", r.IamPolicy.Validate(r.Name))
 	}
 
 	if r.NestedQuery != nil {
-		r.NestedQuery.Validate(r.Name)
+		errs.Nest("nested_query", r.NestedQuery.Validate(r.Name))
+	}
+
+	if r.ListDatasource != nil {
+		errs.Nest("list_datasource", r.validateListDatasource())
+	}
+
+	if r.Batching != nil {
+		errs.Nest("batching", r.validateBatching())
+	}
+
+	if r.PluginFramework {
+		errs.Nest("", r.validatePluginFramework())
+	}
+
+	ephemeralNames := map[string]bool{}
+	for _, e := range r.EphemeralResources {
+		if ephemeralNames[e.Name] {
+			errs.Addf(fmt.Sprintf("ephemeral_resources[%s]", e.Name), "Duplicate ephemeral resource %s in resource %s", e.Name, r.Name)
+		}
+		ephemeralNames[e.Name] = true
+		errs.Nest(fmt.Sprintf("ephemeral_resources[%s]", e.Name), e.Validate(r.Name))
 	}
 
 	for _, example := range r.Examples {
-		example.Validate(r.Name)
+		errs.Nest(fmt.Sprintf("examples[%s]", example.Name), example.Validate(r.Name))
 	}
 
 	if r.Async != nil {
-		r.Async.Validate()
+		errs.Nest("async", r.Async.Validate())
 	}
+
+	return errs
 }
 
 // ====================
@@ -938,6 +996,16 @@ func (r Resource) RootLabels() bool {
 	return false
 }
 
+// Check if the resource has root "annotations" field
+func (r Resource) RootAnnotations() bool {
+	for _, p := range r.RootProperties() {
+		if p.IsA("KeyValueAnnotations") {
+			return true
+		}
+	}
+	return false
+}
+
 // Return labels fields that should be added to ImportStateVerifyIgnore
 func (r Resource) IgnoreReadLabelsFields(props []*Type) []string {
 	fields := make([]string, 0)
@@ -1186,6 +1254,135 @@ func (r Resource) ImportIdFormatsFromResource() []string {
 	return ImportIdFormats(r.ImportFormat, r.Identity, r.BaseUrl)
 }
 
+// Returns the attributes of the resource identity schema: the fields of the
+// first import id format, e.g. project, region and name. It returns nil if
+// the resource has no identity schema, see IdentityExclusion.
+func (r Resource) IdentitySchemaFields() []string {
+	fields, _ := r.identityFields()
+	return fields
+}
+
+// Returns why the resource has no identity schema, or "" if it has one.
+func (r Resource) IdentityExclusion() string {
+	_, reason := r.identityFields()
+	return reason
+}
+
+func (r Resource) identityFields() ([]string, string) {
+	if r.ExcludeRead {
+		return nil, "exclude_read is set, so the identity cannot be read"
+	}
+	if r.PluginFramework {
+		return nil, "plugin_framework resources do not support resource identity"
+	}
+	return r.importFormatFields(func(p *Type) bool {
+		tfType := p.TFType(p.Type)
+		return tfType == "schema.TypeString" || tfType == "schema.TypeInt"
+	})
+}
+
+// Returns the fields of the first import id format, or why one of them is
+// neither the project nor a top-level property accepted by valid.
+func (r Resource) importFormatFields(valid func(*Type) bool) ([]string, string) {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.ImportIdFormatsFromResource()[0]) {
+		if slices.Contains(fields, f) {
+			continue
+		}
+		if f == "project" && r.HasProject() {
+			fields = append(fields, f)
+			continue
+		}
+		p := r.identityProperty(f)
+		if p == nil {
+			return nil, fmt.Sprintf("import format field %s is not a top-level property", f)
+		}
+		if p.FlattenObject {
+			return nil, fmt.Sprintf("import format field %s is a flattened object", f)
+		}
+		if !valid(p) {
+			return nil, fmt.Sprintf("import format field %s has type %s", f, p.Type)
+		}
+		fields = append(fields, f)
+	}
+	return fields, ""
+}
+
+func (r Resource) identityProperty(field string) *Type {
+	idx := slices.IndexFunc(r.AllUserProperties(), func(p *Type) bool {
+		return google.Underscore(p.Name) == field
+	})
+	if idx < 0 {
+		return nil
+	}
+	return r.AllUserProperties()[idx]
+}
+
+// Returns the schema type of an identity attribute, e.g. schema.TypeString.
+func (r Resource) IdentityFieldType(field string) string {
+	if p := r.identityProperty(field); p != nil {
+		return p.TFType(p.Type)
+	}
+	return "schema.TypeString"
+}
+
+// Returns the fields of the first import id format, or nil if a field is
+// neither the project nor a top-level string property.
+func (r Resource) ImportFields() []string {
+	fields, _ := r.frameworkImportFields()
+	return fields
+}
+
+func (r Resource) frameworkImportFields() ([]string, string) {
+	return r.importFormatFields(func(p *Type) bool {
+		return slices.Contains([]string{"String", "Enum", "ResourceRef"}, p.Type)
+	})
+}
+
+// Returns whether a list resource, used by Terraform `list` blocks, is
+// generated. The resource needs to be importable, with an identity schema
+// and a collection URL whose variables are all string identity attributes,
+// so that listed objects can be identified.
+func (r Resource) ShouldGenerateListResource() bool {
+	if r.ListResource != nil && r.ListResource.Exclude {
+		return false
+	}
+	if r.PluginFramework || r.NestedQuery != nil || r.BaseUrl == "" || r.SelfLinkUri() == r.BaseUrl {
+		return false
+	}
+	identity := r.IdentitySchemaFields()
+	if len(identity) == 0 || r.ExcludeImport {
+		return false
+	}
+	parents := r.ListResourceParentFields()
+	if len(parents) >= len(identity) {
+		return false
+	}
+	for _, f := range parents {
+		if !slices.Contains(identity, f) || r.IdentityFieldType(f) != "schema.TypeString" {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the variables of the collection URL, which are the arguments of
+// the list resource, e.g. project and location.
+func (r Resource) ListResourceParentFields() []string {
+	return r.collectionUrlFields()
+}
+
+// Returns whether the list resource has a `filter` argument.
+func (r Resource) ListResourceFilter() bool {
+	return r.ListResource != nil && r.ListResource.Filter
+}
+
+// Returns whether an identity attribute can be omitted on import, because
+// it defaults to the provider configuration.
+func (r Resource) IdentityFieldOptionalForImport(field string) bool {
+	return field == "project" || field == "region" || field == "zone"
+}
+
 // Returns a list of import id formats for a given resource. If an id
 // contains provider-default values, this fn will return formats both
 // including and omitting the value.
@@ -1735,27 +1932,31 @@ func (r Resource) CustomTemplate(templatePath string, appendNewline bool) string
 	return output
 }
 
+// Templates parsed together with every custom template, which can use the
+// templates they define.
+var PartialTemplates = []string{
+	"templates/terraform/expand_resource_ref.tmpl",
+	"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl",
+	"templates/terraform/flatten_property_method.go.tmpl",
+	"templates/terraform/expand_property_method.go.tmpl",
+	"templates/terraform/update_mask.go.tmpl",
+	"templates/terraform/nested_query.go.tmpl",
+	"templates/terraform/unordered_list_customize_diff.go.tmpl",
+}
+
 func ExecuteTemplate(e any, templatePath string, appendNewline bool) string {
-	templates := []string{
-		templatePath,
-		"templates/terraform/expand_resource_ref.tmpl",
-		"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl",
-		"templates/terraform/flatten_property_method.go.tmpl",
-		"templates/terraform/expand_property_method.go.tmpl",
-		"templates/terraform/update_mask.go.tmpl",
-		"templates/terraform/nested_query.go.tmpl",
-		"templates/terraform/unordered_list_customize_diff.go.tmpl",
-	}
+	templates := append([]string{templatePath}, PartialTemplates...)
 	templateFileName := filepath.Base(templatePath)
 
 	tmpl, err := template.New(templateFileName).Funcs(google.TemplateFunctions).ParseFiles(templates...)
 	if err != nil {
-		glog.Exit(err)
+		// Templates calling this return the panic as an error
+		panic(err)
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, e); err != nil {
-		glog.Exit(err)
+		panic(err)
 	}
 
 	rs := contents.String()
@@ -2082,6 +2283,253 @@ func (r Resource) ShouldGenerateSingularDataSource() bool {
 	return r.GenerateDatasource
 }
 
+func (r Resource) ShouldGenerateListDataSource() bool {
+	return r.ListDatasource != nil
+}
+
+// ListDataSourceName returns the Terraform name of the plural data source.
+func (r Resource) ListDataSourceName() string {
+	if r.ListDatasource != nil && r.ListDatasource.Name != "" {
+		return r.ListDatasource.Name
+	}
+	return google.Plural(r.TerraformName())
+}
+
+// ListDataSourceResourceName returns the Go name used for the plural data
+// source's functions, e.g. RedisInstances.
+func (r Resource) ListDataSourceResourceName() string {
+	return google.Plural(r.ResourceName())
+}
+
+// ListDataSourceItemsField returns the name of the attribute holding the
+// listed objects, e.g. instances.
+func (r Resource) ListDataSourceItemsField() string {
+	return google.Underscore(google.Plural(r.Name))
+}
+
+// ListDataSourceUrl returns the URL template of the collection the plural
+// data source lists, under the configurable base path of the product.
+func (r Resource) ListDataSourceUrl() string {
+	return fmt.Sprintf("{{%sBasePath}}%s", r.ProductMetadata.Name, r.collectionUri())
+}
+
+// ListDataSourceItemUrlFields returns the fields of the collection URL that
+// are set on every listed object, as the API does not return them.
+func (r Resource) ListDataSourceItemUrlFields() []string {
+	return google.Select(r.collectionUrlFields(), func(f string) bool {
+		if f == "project" {
+			return true
+		}
+		return slices.ContainsFunc(r.AllUserProperties(), func(p *Type) bool {
+			return p.UrlParamOnly && google.Underscore(p.Name) == f
+		})
+	})
+}
+
+// ListDataSourceRequiredFields returns the fields of the collection URL that
+// are required arguments of the plural data source.
+func (r Resource) ListDataSourceRequiredFields() []string {
+	return google.Reject(r.collectionUrlFields(), func(f string) bool {
+		return f == "region" || f == "project" || f == "zone"
+	})
+}
+
+// ListDataSourceOptionalFields returns the fields of the collection URL that
+// default to the provider configuration.
+func (r Resource) ListDataSourceOptionalFields() []string {
+	return google.Select(r.collectionUrlFields(), func(f string) bool {
+		return f == "region" || f == "project" || f == "zone"
+	})
+}
+
+// ListDataSourceFilterProperties returns the properties the plural data
+// source filters on client-side.
+func (r Resource) ListDataSourceFilterProperties() []*Type {
+	var props []*Type
+	if r.ListDatasource == nil {
+		return props
+	}
+	for _, name := range r.ListDatasource.FilterProperties {
+		for _, p := range r.ReadProperties() {
+			if p.Name == name {
+				props = append(props, p)
+			}
+		}
+	}
+	return props
+}
+
+// FixtureTestExample returns the example that the generated acceptance tests
+// of plural data sources and ephemeral resources create objects with, or nil
+// if there is none.
+func (r Resource) FixtureTestExample() *resource.Examples {
+	for _, e := range r.TestExamples() {
+		if e.SkipTest == "" && len(e.ExternalProviders) == 0 {
+			return &e
+		}
+	}
+	return nil
+}
+
+// Returns the {{field}} variables of the collection URL, in order.
+func (r Resource) collectionUrlFields() []string {
+	var fields []string
+	for _, m := range regexp.MustCompile(`{{(\w+)}}`).FindAllStringSubmatch(r.collectionUri(), -1) {
+		if !slices.Contains(fields, m[1]) {
+			fields = append(fields, m[1])
+		}
+	}
+	return fields
+}
+
+func (r Resource) validateListDatasource() google.ValidationErrors {
+	errs := r.ListDatasource.Validate(r.Name)
+	if r.NestedQuery != nil {
+		errs.Addf("", "`list_datasource` is not supported for resources with `nested_query` in resource %s", r.Name)
+	}
+	if r.ExcludeRead {
+		errs.Addf("", "`list_datasource` requires a read method in resource %s", r.Name)
+	}
+	// The data source lists the base URL, which must be the collection.
+	if r.BaseUrl == "" || r.SelfLinkUri() == r.BaseUrl {
+		errs.Addf("", "`list_datasource` requires a `base_url` that differs from the self link in resource %s", r.Name)
+	}
+	for i, name := range r.ListDatasource.FilterProperties {
+		idx := slices.IndexFunc(r.ReadProperties(), func(p *Type) bool { return p.Name == name })
+		if idx < 0 {
+			errs.Addf(fmt.Sprintf("filter_properties[%d]", i), "Missing top-level property %s in resource %s", name, r.Name)
+			continue
+		}
+		p := r.ReadProperties()[idx]
+		if !slices.Contains([]string{"String", "Enum", "Integer", "Boolean"}, p.Type) || p.FlattenObject {
+			errs.Addf(fmt.Sprintf("filter_properties[%d]", i), "Property %s of type %s in resource %s cannot be used as a filter", name, p.Type, r.Name)
+		}
+	}
+	return errs
+}
+
+func (r Resource) validateBatching() google.ValidationErrors {
+	errs := r.Batching.Validate(r.Name)
+	if r.NestedQuery != nil && r.NestedQuery.ModifyByPatch {
+		errs.Addf("", "`batching` is not supported for resources with `nested_query.modify_by_patch` in resource %s", r.Name)
+	}
+	if r.Batching.Update != nil && r.UpdateMask {
+		errs.Addf("update", "Batched updates are not supported for resources with `update_mask` in resource %s", r.Name)
+	}
+	if r.Batching.Delete != nil && r.CustomCode.CustomDelete != "" {
+		errs.Addf("delete", "Batched deletes are not supported for resources with `custom_code.custom_delete` in resource %s", r.Name)
+	}
+	// Deletes have no request body unless custom code sets one, and there
+	// would be nothing to combine.
+	if r.Batching.Delete != nil && r.CustomCode.PreDelete == "" {
+		errs.Addf("delete", "Batched deletes require `custom_code.pre_delete` to set the request body `obj` in resource %s", r.Name)
+	}
+	uris := map[string]string{
+		"create": r.CreateUri(),
+		"update": r.UpdateUri(),
+		"delete": r.DeleteUri(),
+	}
+	for _, op := range []string{"create", "update", "delete"} {
+		o := r.Batching.Operation(op)
+		if o == nil {
+			continue
+		}
+		// Query parameters such as the id of a created resource are only in
+		// the URL of single requests, so they would be lost.
+		if strings.Contains(uris[op], "?") {
+			errs.Addf(op, "Batched %ss are not supported for resources with query parameters in the %s URL in resource %s", op, op, r.Name)
+		}
+		if o.SplitStrategy != "by_index" {
+			continue
+		}
+		if async := r.GetAsync(); async != nil && async.Allow(op) {
+			errs.Addf(op, "`split_strategy: by_index` requires a synchronous %s in resource %s", op, r.Name)
+		}
+	}
+	return errs
+}
+
+// BatchOperation returns the batching configuration of the given operation,
+// or nil if its requests are not batched.
+func (r Resource) BatchOperation(op string) *resource.BatchOperation {
+	if r.Batching == nil {
+		return nil
+	}
+	return r.Batching.Operation(op)
+}
+
+// Validates that the resource only uses features that plugin-framework
+// resources support. Custom code and the other hooks into the SDKv2
+// templates are not supported.
+func (r Resource) validatePluginFramework() google.ValidationErrors {
+	var errs google.ValidationErrors
+	unsupported := []struct {
+		field string
+		set   bool
+	}{
+		{"custom_code", r.CustomCode != resource.CustomCode{}},
+		{"custom_diff", len(r.FrameworkCustomDiff()) > 0},
+		{"mutex", r.Mutex != ""},
+		{"nested_query", r.NestedQuery != nil},
+		{"batching", r.Batching != nil},
+		{"virtual_fields", len(r.VirtualFields) > 0},
+		{"state_upgraders", r.StateUpgraders || r.SchemaVersion > 0},
+		{"exclude_read", r.ExcludeRead},
+		{"read_error_transform", r.ReadErrorTransform != ""},
+		{"legacy_long_form_project", r.LegacyLongFormProject},
+		{"generate_datasource", r.GenerateDatasource},
+		{"list_datasource", r.ListDatasource != nil},
+		{"include_in_tgc_next_DO_NOT_USE", r.IncludeInTGCNext},
+	}
+	for _, u := range unsupported {
+		if u.set {
+			errs.Addf(u.field, "`%s` is not supported by plugin_framework in resource %s", u.field, r.Name)
+		}
+	}
+	if async := r.GetAsync(); async != nil && !async.IsA("OpAsync") {
+		errs.Addf("async", "Only OpAsync is supported by plugin_framework in resource %s", r.Name)
+	}
+	if _, reason := r.frameworkImportFields(); !r.ExcludeImport && reason != "" {
+		errs.Addf("import_format", "The fields of the first import format must be top-level strings for plugin_framework in resource %s: %s", r.Name, reason)
+	}
+	for _, p := range r.AllUserProperties() {
+		path := fmt.Sprintf("properties[%s]", p.Name)
+		if slices.Contains(r.UserParameters(), p) {
+			path = fmt.Sprintf("parameters[%s]", p.Name)
+		}
+		errs.Nest(path, p.validatePluginFramework(r.Name))
+	}
+	return errs
+}
+
+// Returns the custom_diff functions of a plugin-framework resource, without
+// those added for root labels and annotations, which are planned by the
+// ModifyPlan method of the generated resource.
+func (r Resource) FrameworkCustomDiff() []string {
+	return google.Reject(r.CustomDiff, func(f string) bool {
+		return slices.Contains([]string{
+			"tpgresource.SetLabelsDiff",
+			"tpgresource.SetLabelsDiffWithoutAttributionLabel",
+			"tpgresource.SetAnnotationsDiff",
+		}, f)
+	})
+}
+
+// Returns the NestedObject properties, and the NestedObject items of Array
+// properties, of a plugin-framework resource at any depth. A model struct is
+// generated for each of them.
+func (r Resource) FrameworkNestedObjects() []*Type {
+	var objects []*Type
+	for _, p := range r.AllNestedProperties(r.AllUserProperties()) {
+		if p.Type == "NestedObject" {
+			objects = append(objects, p)
+		} else if p.Type == "Array" && p.ItemType.Type == "NestedObject" {
+			objects = append(objects, p.ItemType)
+		}
+	}
+	return objects
+}
+
 // DatasourceOptionalFields returns a list of fields from the resource's URI
 // that should be marked as "Required".
 func (r Resource) DatasourceRequiredFields() []string {
@@ -0,0 +1,160 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Batching declares which requests of a resource can be combined with the
+// same requests of other resources into a single call to a batch endpoint.
+// Batched requests are sent through the product's batcher in the provider
+// config, and are only combined when batching is enabled in the provider's
+// `batching` block.
+type Batching struct {
+	Create *BatchOperation `yaml:"create,omitempty"`
+	Update *BatchOperation `yaml:"update,omitempty"`
+	Delete *BatchOperation `yaml:"delete,omitempty"`
+}
+
+// BatchOperation describes how one kind of request is batched.
+type BatchOperation struct {
+	// The URL of the batch endpoint, relative to the product's base URL, e.g.
+	// "projects/{{project}}/locations/{{location}}/things:batchCreate".
+	// Requests are only combined when their batch URLs are the same.
+	BatchUrl string `yaml:"batch_url"`
+
+	// The HTTP verb used to call the batch endpoint. Defaults to POST.
+	BatchVerb string `yaml:"batch_verb,omitempty"`
+
+	// An optional template added to the batch URL to group requests, e.g.
+	// "{{parent}}". Requests are only combined when the expanded keys are the
+	// same. Requests of different operations are never combined.
+	KeyTemplate string `yaml:"key_template,omitempty"`
+
+	// How the bodies of the requests are combined, one of:
+	// - list_append: top-level lists are concatenated. Any other top-level
+	//   field must have the same value in every request.
+	// - map_merge: bodies are merged recursively. A field must not have
+	//   different values in two requests.
+	// A request that can't be combined with a started batch is sent on its
+	// own.
+	CombineStrategy string `yaml:"combine_strategy"`
+
+	// If set, the request body is sent as a single item list under this
+	// field, e.g. "requests".
+	BodyField string `yaml:"body_field,omitempty"`
+
+	// How the batch response is returned to each request, one of:
+	// - whole (default): every request gets the whole response.
+	// - by_index: every request gets the item of `response_field` at the
+	//   same offset as its item in `body_field`. An item with an `error`, or
+	//   a `status` with a non-zero code, fails only the request it belongs to.
+	SplitStrategy string `yaml:"split_strategy,omitempty"`
+
+	// The response list split by the by_index strategy, e.g. "responses".
+	ResponseField string `yaml:"response_field,omitempty"`
+
+	operation string
+}
+
+func (b *Batching) SetDefault() {
+	for op, o := range b.operations() {
+		o.operation = op
+		if o.BatchVerb == "" {
+			o.BatchVerb = "POST"
+		}
+		if o.SplitStrategy == "" {
+			o.SplitStrategy = "whole"
+		}
+	}
+}
+
+func (b *Batching) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if len(b.operations()) == 0 {
+		errs.Addf("", "Missing `create`, `update` or `delete` for `batching` in resource %s", rName)
+	}
+	for _, op := range []string{"create", "update", "delete"} {
+		if o := b.Operation(op); o != nil {
+			errs.Nest(op, o.Validate(rName))
+		}
+	}
+	return errs
+}
+
+// operations returns the batched operations by name.
+func (b *Batching) operations() map[string]*BatchOperation {
+	ops := map[string]*BatchOperation{}
+	if b.Create != nil {
+		ops["create"] = b.Create
+	}
+	if b.Update != nil {
+		ops["update"] = b.Update
+	}
+	if b.Delete != nil {
+		ops["delete"] = b.Delete
+	}
+	return ops
+}
+
+// Operation returns the batched operation with the given name, or nil.
+func (b *Batching) Operation(op string) *BatchOperation {
+	return b.operations()[strings.ToLower(op)]
+}
+
+func (o *BatchOperation) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if o.BatchUrl == "" {
+		errs.Addf("batch_url", "Missing `batch_url` for `batching` in resource %s", rName)
+	}
+
+	allowed := []string{"POST", "PUT", "PATCH"}
+	if !slices.Contains(allowed, o.BatchVerb) {
+		errs.Addf("batch_verb", "Value on `batch_verb` should be one of %#v", allowed)
+	}
+
+	allowed = []string{"list_append", "map_merge"}
+	if !slices.Contains(allowed, o.CombineStrategy) {
+		errs.Addf("combine_strategy", "Value on `combine_strategy` should be one of %#v", allowed)
+	}
+
+	allowed = []string{"whole", "by_index"}
+	if !slices.Contains(allowed, o.SplitStrategy) {
+		errs.Addf("split_strategy", "Value on `split_strategy` should be one of %#v", allowed)
+	}
+
+	if o.SplitStrategy == "by_index" {
+		if o.CombineStrategy != "list_append" {
+			errs.Addf("split_strategy", "`split_strategy: by_index` requires `combine_strategy: list_append` in resource %s", rName)
+		}
+		if o.BodyField == "" {
+			errs.Addf("body_field", "`split_strategy: by_index` requires `body_field` in resource %s", rName)
+		}
+		if o.ResponseField == "" {
+			errs.Addf("response_field", "`split_strategy: by_index` requires `response_field` in resource %s", rName)
+		}
+	}
+	return errs
+}
+
+// BatchKeyTemplate returns the template of the key grouping the requests
+// that are combined.
+func (o BatchOperation) BatchKeyTemplate() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", o.operation, o.BatchUrl, o.KeyTemplate))
+}
@@ -16,7 +16,6 @@ package resource
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,7 +25,6 @@ import (
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
-	"github.com/golang/glog"
 )
 
 type IamMember struct {
@@ -212,11 +210,13 @@ func (e *Examples) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (e *Examples) Validate(rName string) {
+func (e *Examples) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	if e.Name == "" {
-		log.Fatalf("Missing `name` for one example in resource %s", rName)
+		errs.Addf("name", "Missing `name` for one example in resource %s", rName)
 	}
-	e.ValidateExternalProviders()
+	errs.Nest("", e.ValidateExternalProviders())
+	return errs
 }
 
 func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) {
@@ -230,12 +230,12 @@ func validateRegexForContents(r *regexp.Regexp, contents string, configPath stri
 			}
 		}
 		if !found {
-			log.Fatalf("Failed to find %s environment variable defined in YAML file when validating the file %s. Please define this in %s", v[1], configPath, objName)
+			panic(fmt.Errorf("Failed to find %s environment variable defined in YAML file when validating the file %s. Please define this in %s", v[1], configPath, objName))
 		}
 	}
 }
 
-func (e *Examples) ValidateExternalProviders() {
+func (e *Examples) ValidateExternalProviders() google.ValidationErrors {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -250,9 +250,11 @@ func (e *Examples) ValidateExternalProviders() {
 		}
 	}
 
+	var errs google.ValidationErrors
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		errs.Addf("external_providers", "Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
+	return errs
 }
 
 // Executes example templates for documentation and tests
@@ -339,7 +341,7 @@ func (e *Examples) SetHCLText() {
 func (e *Examples) ExecuteTemplate() string {
 	templateContent, err := os.ReadFile(e.ConfigPath)
 	if err != nil {
-		glog.Exit(err)
+		panic(err)
 	}
 
 	fileContentString := string(templateContent)
@@ -354,12 +356,13 @@ func (e *Examples) ExecuteTemplate() string {
 
 	tmpl, err := template.New(templateFileName).Funcs(google.TemplateFunctions).Parse(fileContentString)
 	if err != nil {
-		glog.Exit(err)
+		// Templates calling this return the panic as an error
+		panic(err)
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, e); err != nil {
-		glog.Exit(err)
+		panic(err)
 	}
 
 	rs := contents.String()
@@ -14,8 +14,9 @@
 package resource
 
 import (
-	"log"
 	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 // Information about the IAM policy for this resource
@@ -139,19 +140,21 @@ func (p *IamPolicy) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *IamPolicy) Validate(rName string) {
+func (p *IamPolicy) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	allowed := []string{"GET", "POST"}
 	if !slices.Contains(allowed, p.FetchIamPolicyVerb) {
-		log.Fatalf("Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("fetch_iam_policy_verb", "Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
 	}
 
 	allowed = []string{"POST", "PUT"}
 	if !slices.Contains(allowed, p.SetIamPolicyVerb) {
-		log.Fatalf("Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("set_iam_policy_verb", "Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
 	}
 
 	allowed = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
 	if p.IamConditionsRequestType != "" && !slices.Contains(allowed, p.IamConditionsRequestType) {
-		log.Fatalf("Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("iam_conditions_request_type", "Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)
 	}
+	return errs
 }
@@ -0,0 +1,62 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// ListDatasource configures a plural data source that lists every object of
+// the resource under a parent, for example all instances in a location. The
+// data source calls the resource's collection URL, follows nextPageToken, and
+// flattens each item with the resource's flatteners.
+//
+// Defining the block, even empty, enables generation.
+type ListDatasource struct {
+	// Overrides the Terraform name of the data source. Defaults to the
+	// resource's Terraform name, pluralized, e.g. google_redis_instances.
+	Name string `yaml:"name,omitempty"`
+
+	// If true, adds a `filter` argument that is sent to the API as the
+	// `filter` query parameter. Only set this for APIs that support AIP-160
+	// filtering on the list method.
+	Filter bool `yaml:"filter,omitempty"`
+
+	// If true, adds an `order_by` argument that is sent to the API as the
+	// `orderBy` query parameter.
+	OrderBy bool `yaml:"order_by,omitempty"`
+
+	// Top-level properties, by name, that can be used to filter the listed
+	// objects on the client. Each becomes an optional argument, and only
+	// objects whose flattened value equals the argument are returned. Only
+	// String, Enum, Integer and Boolean properties are supported.
+	FilterProperties []string `yaml:"filter_properties,omitempty"`
+}
+
+func (l *ListDatasource) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	seen := map[string]bool{}
+	for i, p := range l.FilterProperties {
+		if seen[p] {
+			errs.Addf(fmt.Sprintf("filter_properties[%d]", i), "Duplicate filter property %s in resource %s", p, rName)
+		}
+		seen[p] = true
+		if p == "filter" || p == "order_by" {
+			errs.Addf(fmt.Sprintf("filter_properties[%d]", i), "Filter property %s in resource %s conflicts with a data source argument", p, rName)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// ListResource configures the list resource of a resource, used by
+// Terraform `list` blocks to discover existing objects. List resources are
+// generated for every eligible resource, see Resource.ShouldGenerateListResource,
+// so the block is only needed to change the defaults.
+type ListResource struct {
+	// If true, no list resource is generated.
+	Exclude bool `yaml:"exclude,omitempty"`
+
+	// If true, adds a `filter` argument that is sent to the API as the
+	// `filter` query parameter. Only set this for APIs that support AIP-160
+	// filtering on the list method.
+	Filter bool `yaml:"filter,omitempty"`
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -833,3 +834,527 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 	})
 }
+
+func TestListDataSource(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		Name:           "Instance",
+		BaseUrl:        "projects/{{project}}/locations/{{location}}/instances",
+		ListDatasource: &resource.ListDatasource{FilterProperties: []string{"state", "config", "missing"}},
+		Properties: []*Type{
+			{Name: "state", Type: "Enum"},
+			{Name: "config", Type: "NestedObject"},
+		},
+		Parameters: []*Type{
+			{Name: "location", Type: "String", UrlParamOnly: true},
+		},
+		ProductMetadata: &Product{Name: "Redis"},
+	}
+
+	if got, want := r.ListDataSourceName(), "google_redis_instances"; got != want {
+		t.Errorf("expected name %s, got %s", want, got)
+	}
+	if got, want := r.ListDataSourceItemsField(), "instances"; got != want {
+		t.Errorf("expected items field %s, got %s", want, got)
+	}
+	if got, want := r.ListDataSourceRequiredFields(), []string{"location"}; !slices.Equal(got, want) {
+		t.Errorf("expected required fields %v, got %v", want, got)
+	}
+	if got, want := r.ListDataSourceOptionalFields(), []string{"project"}; !slices.Equal(got, want) {
+		t.Errorf("expected optional fields %v, got %v", want, got)
+	}
+	if got, want := r.ListDataSourceUrl(), "{{RedisBasePath}}projects/{{project}}/locations/{{location}}/instances"; got != want {
+		t.Errorf("expected url %s, got %s", want, got)
+	}
+	if got, want := r.ListDataSourceItemUrlFields(), []string{"project", "location"}; !slices.Equal(got, want) {
+		t.Errorf("expected item url fields %v, got %v", want, got)
+	}
+
+	var paths []string
+	for _, e := range r.validateListDatasource() {
+		paths = append(paths, e.Path)
+	}
+	if want := []string{"filter_properties[1]", "filter_properties[2]"}; !slices.Equal(paths, want) {
+		t.Errorf("expected errors at %v, got %v", want, paths)
+	}
+
+	r.SelfLink = r.BaseUrl
+	paths = nil
+	for _, e := range r.validateListDatasource() {
+		paths = append(paths, e.Path)
+	}
+	if want := []string{"", "filter_properties[1]", "filter_properties[2]"}; !slices.Equal(paths, want) {
+		t.Errorf("expected errors at %v, got %v", want, paths)
+	}
+
+	r.ListDatasource.Name = "google_redis_all_instances"
+	if got := r.ListDataSourceName(); got != r.ListDatasource.Name {
+		t.Errorf("expected the name override, got %s", got)
+	}
+}
+
+func TestValidateBatching(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		wantPaths   []string
+	}{
+		{
+			description: "valid",
+			obj: Resource{
+				Name:            "Thing",
+				Mutex:           "things/{{project}}",
+				ProductMetadata: &Product{Name: "Things"},
+				CustomCode:      resource.CustomCode{PreDelete: "templates/terraform/pre_delete/thing.go.tmpl"},
+				Batching: &resource.Batching{
+					Create: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchCreate",
+						CombineStrategy: "list_append",
+						SplitStrategy:   "by_index",
+						BodyField:       "requests",
+						ResponseField:   "things",
+					},
+					Delete: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchDelete",
+						CombineStrategy: "map_merge",
+					},
+				},
+			},
+		},
+		{
+			description: "invalid strategies",
+			obj: Resource{
+				Name:            "Thing",
+				ProductMetadata: &Product{Name: "Things"},
+				Batching: &resource.Batching{
+					Create: &resource.BatchOperation{
+						BatchVerb:       "GET",
+						CombineStrategy: "concat",
+						SplitStrategy:   "by_name",
+					},
+					Update: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchUpdate",
+						CombineStrategy: "map_merge",
+						SplitStrategy:   "by_index",
+					},
+				},
+			},
+			wantPaths: []string{
+				"create.batch_url", "create.batch_verb", "create.combine_strategy", "create.split_strategy",
+				"update.body_field", "update.response_field", "update.split_strategy",
+			},
+		},
+		{
+			description: "unsupported resource features",
+			obj: Resource{
+				Name:            "Thing",
+				CreateUrl:       "projects/{{project}}/things?thingId={{name}}",
+				UpdateMask:      true,
+				Async:           &Async{Type: "OpAsync", Actions: []string{"create", "delete", "update"}},
+				ProductMetadata: &Product{Name: "Things"},
+				Batching: &resource.Batching{
+					Create: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchCreate",
+						CombineStrategy: "list_append",
+						SplitStrategy:   "by_index",
+						BodyField:       "requests",
+						ResponseField:   "things",
+					},
+					Update: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchUpdate",
+						CombineStrategy: "list_append",
+					},
+					Delete: &resource.BatchOperation{
+						BatchUrl:        "projects/{{project}}/things:batchDelete",
+						CombineStrategy: "list_append",
+					},
+				},
+			},
+			wantPaths: []string{"create", "create", "delete", "update"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.Batching.SetDefault()
+			var paths []string
+			for _, e := range tc.obj.validateBatching() {
+				paths = append(paths, e.Path)
+			}
+			slices.Sort(paths)
+			if !slices.Equal(paths, tc.wantPaths) {
+				t.Errorf("expected errors at %v, got %v", tc.wantPaths, paths)
+			}
+		})
+	}
+}
+
+func TestBatchOperation(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		Name: "Thing",
+		Batching: &resource.Batching{
+			Delete: &resource.BatchOperation{
+				BatchUrl:        "projects/{{project}}/things:batchDelete",
+				KeyTemplate:     "{{location}}",
+				CombineStrategy: "list_append",
+			},
+		},
+	}
+	r.Batching.SetDefault()
+
+	if r.BatchOperation("create") != nil {
+		t.Errorf("expected creates not to be batched")
+	}
+	o := r.BatchOperation("Delete")
+	if o == nil {
+		t.Fatalf("expected deletes to be batched")
+	}
+	if o.BatchVerb != "POST" || o.SplitStrategy != "whole" {
+		t.Errorf("expected defaults POST and whole, got %s and %s", o.BatchVerb, o.SplitStrategy)
+	}
+	if got, want := o.BatchKeyTemplate(), "delete projects/{{project}}/things:batchDelete {{location}}"; got != want {
+		t.Errorf("expected key template %q, got %q", want, got)
+	}
+}
+
+func TestIdentitySchemaFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description   string
+		obj           Resource
+		want          []string
+		wantExclusion string
+	}{
+		{
+			description: "project, location and name",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/locations/{{location}}/instances",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+			want: []string{"project", "location", "name"},
+		},
+		{
+			description: "import format with a camel case parameter",
+			obj: Resource{
+				BaseUrl:      "projects/{{project}}/secrets",
+				ImportFormat: []string{"projects/{{project}}/secrets/{{secret_id}}"},
+				Parameters: []*Type{
+					{Name: "secretId", Type: "String"},
+				},
+			},
+			want: []string{"project", "secret_id"},
+		},
+		{
+			description: "integer field",
+			obj: Resource{
+				BaseUrl:      "projects/{{project}}/things",
+				ImportFormat: []string{"projects/{{project}}/things/{{number}}"},
+				Properties: []*Type{
+					{Name: "number", Type: "Integer"},
+				},
+			},
+			want: []string{"project", "number"},
+		},
+		{
+			description: "object field",
+			obj: Resource{
+				BaseUrl:      "projects/{{project}}/things",
+				ImportFormat: []string{"projects/{{project}}/things/{{config}}"},
+				Properties: []*Type{
+					{Name: "config", Type: "NestedObject"},
+				},
+			},
+			wantExclusion: "import format field config has type NestedObject",
+		},
+		{
+			description: "field missing from the schema",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/things",
+			},
+			wantExclusion: "import format field name is not a top-level property",
+		},
+		{
+			description: "custom import",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/things",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				CustomCode: resource.CustomCode{CustomImport: "templates/terraform/custom_import/thing.go.tmpl"},
+			},
+			want: []string{"project", "name"},
+		},
+		{
+			description: "exclude import",
+			obj: Resource{
+				BaseUrl:       "projects/{{project}}/things",
+				ExcludeImport: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			want: []string{"project", "name"},
+		},
+		{
+			description: "exclude read",
+			obj: Resource{
+				BaseUrl:     "projects/{{project}}/things",
+				ExcludeRead: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantExclusion: "exclude_read is set, so the identity cannot be read",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.IdentitySchemaFields(); !slices.Equal(got, tc.want) {
+				t.Errorf("expected identity fields %v, got %v", tc.want, got)
+			}
+			if got := tc.obj.IdentityExclusion(); got != tc.wantExclusion {
+				t.Errorf("expected identity exclusion %q, got %q", tc.wantExclusion, got)
+			}
+		})
+	}
+}
+
+func TestShouldGenerateListResource(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		want        bool
+	}{
+		{
+			description: "collection of named objects",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/locations/{{location}}/instances",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+			want: true,
+		},
+		{
+			description: "excluded",
+			obj: Resource{
+				BaseUrl:      "projects/{{project}}/locations/{{location}}/instances",
+				ListResource: &resource.ListResource{Exclude: true},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "exclude import",
+			obj: Resource{
+				BaseUrl:       "projects/{{project}}/locations/{{location}}/instances",
+				ExcludeImport: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "plugin framework",
+			obj: Resource{
+				BaseUrl:         "projects/{{project}}/locations/{{location}}/instances",
+				PluginFramework: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "singleton",
+			obj: Resource{
+				BaseUrl:  "projects/{{project}}/settings",
+				SelfLink: "projects/{{project}}/settings",
+			},
+		},
+		{
+			description: "collection URL variable outside the identity",
+			obj: Resource{
+				BaseUrl:      "{{secret}}/versions",
+				ImportFormat: []string{"projects/{{project}}/secrets/{{secret_id}}/versions/{{version}}"},
+				Properties: []*Type{
+					{Name: "version", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "secret", Type: "ResourceRef"},
+					{Name: "secretId", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "nested query",
+			obj: Resource{
+				BaseUrl:     "projects/{{project}}/things",
+				NestedQuery: &resource.NestedQuery{Keys: []string{"things"}},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.ShouldGenerateListResource(); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidatePluginFramework(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		wantPaths   []string
+	}{
+		{
+			description: "supported",
+			obj: Resource{
+				Name:    "Thing",
+				BaseUrl: "projects/{{project}}/locations/{{location}}/things",
+				Parameters: []*Type{
+					{Name: "location", Type: "String", UrlParamOnly: true},
+				},
+				CustomDiff: []string{"tpgresource.SetLabelsDiff"},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{Name: "count", Type: "Integer", DefaultValue: 1},
+					{Name: "tags", Type: "Array", ItemType: &Type{Type: "String"}},
+					{Name: "ports", Type: "Array", ItemType: &Type{Type: "Integer"}},
+					{Name: "labels", Type: "KeyValueLabels"},
+					{Name: "terraformLabels", Type: "KeyValueTerraformLabels", Output: true},
+					{Name: "effectiveLabels", Type: "KeyValueEffectiveLabels", Output: true},
+					{
+						Name:         "config",
+						Type:         "NestedObject",
+						ExactlyOneOf: []string{"config", "source_config"},
+						Properties: []*Type{
+							{Name: "enabled", Type: "Boolean", Conflicts: []string{"config.0.rules"}},
+							{Name: "rules", Type: "Array", ItemType: &Type{
+								Type:       "NestedObject",
+								Properties: []*Type{{Name: "expr", Type: "String"}},
+							}},
+						},
+					},
+					{
+						Name:         "sourceConfig",
+						Type:         "NestedObject",
+						ExactlyOneOf: []string{"config", "source_config"},
+						Properties: []*Type{
+							{Name: "uri", Type: "String", Validation: resource.Validation{Regex: "^gs://"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "unsupported resource features",
+			obj: Resource{
+				Name:       "Thing",
+				BaseUrl:    "projects/{{project}}/things",
+				Mutex:      "things/{{project}}",
+				CustomDiff: []string{"tpgresource.DefaultProviderProject"},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantPaths: []string{"custom_diff", "mutex"},
+		},
+		{
+			description: "unsupported import format",
+			obj: Resource{
+				Name:         "Thing",
+				BaseUrl:      "projects/{{project}}/things",
+				ImportFormat: []string{"projects/{{project}}/things/{{number}}"},
+				Properties: []*Type{
+					{Name: "number", Type: "Integer"},
+				},
+			},
+			wantPaths: []string{"import_format"},
+		},
+		{
+			description: "unsupported properties",
+			obj: Resource{
+				Name:    "Thing",
+				BaseUrl: "projects/{{project}}/things",
+				Parameters: []*Type{
+					{Name: "network", Type: "ResourceRef", Resource: "Network", Imports: "selfLink"},
+				},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{
+						Name: "config",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{Name: "size", Type: "Integer", CustomFlatten: "templates/terraform/custom_flatten/size.go.tmpl"},
+							{Name: "labels", Type: "KeyValueLabels"},
+						},
+					},
+				},
+			},
+			wantPaths: []string{"properties[config].properties[size].custom_flatten", "properties[config].properties[labels].type", "parameters[network].type"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(&Product{Name: "Things"})
+			var paths []string
+			for _, e := range tc.obj.validatePluginFramework() {
+				paths = append(paths, e.Path)
+			}
+			if !slices.Equal(paths, tc.wantPaths) {
+				t.Errorf("expected errors at %v, got %v", tc.wantPaths, paths)
+			}
+		})
+	}
+}
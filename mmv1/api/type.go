@@ -16,6 +16,7 @@ package api
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -379,40 +380,45 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
-func (t *Type) Validate(rName string) {
+// Validates the property and its nested properties. Returned errors have
+// key paths relative to the property.
+func (t *Type) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	if t.Name == "" {
-		log.Fatalf("Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+		errs.Addf("name", "Missing `name` for proprty with type %s in resource %s", t.Type, rName)
 	}
 
 	if t.Output && t.Required {
-		log.Fatalf("Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
+		errs.Addf("required", "Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		log.Fatalf("'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
+		errs.Addf("default_value", "'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
 	}
 
 	if t.WriteOnly && (t.DefaultFromApi || t.Output) {
-		log.Fatalf("Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
+		errs.Addf("write_only", "Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
 	}
 
 	if t.WriteOnly && t.Sensitive {
-		log.Fatalf("Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
+		errs.Addf("write_only", "Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
 	}
 
-	t.validateLabelsField()
+	errs.Nest("", t.validateLabelsField())
 
 	switch {
 	case t.IsA("Array"):
-		t.ItemType.Validate(rName)
+		errs.Nest("item_type", t.ItemType.Validate(rName))
 	case t.IsA("Map"):
-		t.ValueType.Validate(rName)
+		errs.Nest("value_type", t.ValueType.Validate(rName))
 	case t.IsA("NestedObject"):
 		for _, p := range t.Properties {
-			p.Validate(rName)
+			errs.Nest(fmt.Sprintf("properties[%s]", p.Name), p.Validate(rName))
 		}
 	default:
 	}
+
+	return errs
 }
 
 // TODO rewrite: add validations
@@ -813,6 +819,199 @@ func (t Type) TFType(s string) string {
 	return "schema.TypeString"
 }
 
+// Returns the plugin framework value type of the property, e.g.
+// types.String, or "" if the type is not supported by plugin-framework
+// resources.
+func (t Type) FrameworkType() string {
+	switch t.Type {
+	case "String", "Enum", "Time", "Timestamp", "Fingerprint", "ResourceRef":
+		return "types.String"
+	case "Integer":
+		return "types.Int64"
+	case "Double":
+		return "types.Float64"
+	case "Boolean":
+		return "types.Bool"
+	case "KeyValuePairs", "KeyValueLabels", "KeyValueTerraformLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations":
+		return "types.Map"
+	case "NestedObject":
+		return "types.Object"
+	case "Array":
+		if t.ItemType == nil {
+			return ""
+		}
+		if t.ItemType.Type == "NestedObject" || slices.Contains([]string{"types.String", "types.Int64", "types.Float64", "types.Bool"}, t.ItemType.FrameworkType()) {
+			return "types.List"
+		}
+	}
+	return ""
+}
+
+// Returns the plugin framework schema attribute of the property, e.g.
+// StringAttribute or ListNestedAttribute.
+func (t Type) FrameworkAttribute() string {
+	switch t.FrameworkType() {
+	case "types.String":
+		return "StringAttribute"
+	case "types.Int64":
+		return "Int64Attribute"
+	case "types.Float64":
+		return "Float64Attribute"
+	case "types.Bool":
+		return "BoolAttribute"
+	case "types.Map":
+		return "MapAttribute"
+	case "types.Object":
+		return "SingleNestedAttribute"
+	case "types.List":
+		if t.ItemType.Type == "NestedObject" {
+			return "ListNestedAttribute"
+		}
+		return "ListAttribute"
+	}
+	return ""
+}
+
+// Returns the Go expression of the plugin framework attr.Type of the
+// property, e.g. types.StringType.
+func (t *Type) FrameworkAttrType() string {
+	switch t.FrameworkType() {
+	case "":
+		return ""
+	case "types.Map":
+		return "types.MapType{ElemType: types.StringType}"
+	case "types.List":
+		return fmt.Sprintf("types.ListType{ElemType: %s}", t.ItemType.FrameworkAttrType())
+	case "types.Object":
+		return fmt.Sprintf("types.ObjectType{AttrTypes: %s{}.attrTypes()}", t.FrameworkModel())
+	}
+	return t.FrameworkType() + "Type"
+}
+
+// Returns the name of the model struct generated for a NestedObject property,
+// or for the items of an Array of NestedObject, in plugin-framework resources.
+func (t *Type) FrameworkModel() string {
+	if t.Type == "Array" {
+		return t.ItemType.FrameworkModel()
+	}
+	return fmt.Sprintf("resource%s%sModel", t.GetPrefix(), t.TitlelizeProperty())
+}
+
+// Returns whether the property is only set by the API in plugin-framework
+// resources, because it or one of its parents is an output.
+func (t Type) FrameworkOutput() bool {
+	return t.Output || (t.ParentMetadata != nil && t.ParentMetadata.FrameworkOutput())
+}
+
+// Returns whether the property is sent to the API in plugin-framework
+// resources. effective_labels is an output that holds the labels sent to the
+// API, instead of labels and terraform_labels.
+func (t Type) FrameworkWrite() bool {
+	if t.UrlParamOnly || t.IgnoreWrite {
+		return false
+	}
+	return !t.FrameworkOutput() || t.IsA("KeyValueEffectiveLabels")
+}
+
+// Returns the plugin framework path expressions of the attributes referenced
+// by conflicts, exactly_one_of and similar fields, which use SDKv2 paths such
+// as "config.0.name".
+func (t Type) FrameworkPathExpressions(paths []string) string {
+	exprs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		exprs = append(exprs, frameworkPathExpression(t.ResourceMetadata.AllUserProperties(), p))
+	}
+	return strings.Join(exprs, ", ")
+}
+
+// Converts an SDKv2 path to a plugin framework path expression. The list
+// indexes of NestedObject properties, which are lists of one item in SDKv2
+// resources, are dropped as they are single nested attributes.
+func frameworkPathExpression(props []*Type, sdkPath string) string {
+	var expr string
+	var prop *Type
+	for _, part := range strings.Split(sdkPath, ".") {
+		if i, err := strconv.Atoi(part); err == nil {
+			if prop != nil && prop.IsA("Array") {
+				expr = fmt.Sprintf("%s.AtListIndex(%d)", expr, i)
+			}
+			continue
+		}
+		if expr == "" {
+			expr = fmt.Sprintf("path.MatchRoot(%q)", part)
+		} else {
+			expr = fmt.Sprintf("%s.AtName(%q)", expr, part)
+		}
+		prop = nil
+		for _, p := range props {
+			if google.Underscore(p.Name) == part {
+				prop = p
+				break
+			}
+		}
+		if prop != nil {
+			props = prop.NestedProperties()
+		}
+	}
+	return expr
+}
+
+// Validates that the property, and its nested properties, only use features
+// that plugin-framework resources support. Returned errors have key paths
+// relative to the property.
+func (t *Type) validatePluginFramework(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if t.FrameworkType() == "" {
+		errs.Addf("type", "Property %s of type %s is not supported by plugin_framework in resource %s", t.Name, t.Type, rName)
+	}
+	if t.Type == "ResourceRef" || (t.Type == "Array" && t.ItemType.Type == "ResourceRef") {
+		errs.Addf("type", "Property %s of type ResourceRef is not supported by plugin_framework in resource %s, as the API may return a different form of the reference", t.Name, rName)
+	}
+
+	unsupported := []struct {
+		field string
+		set   bool
+	}{
+		{"custom_expand", t.CustomExpand != ""},
+		{"custom_flatten", t.CustomFlatten != ""},
+		{"diff_suppress_func", t.DiffSuppressFunc != ""},
+		{"state_func", t.StateFunc != ""},
+		{"flatten_object", t.FlattenObject},
+		{"is_set", t.IsSet},
+		{"unordered_list", t.UnorderedList},
+		{"update_url", t.UpdateUrl != ""},
+	}
+	if t.ParentMetadata != nil {
+		unsupported = append(unsupported, []struct {
+			field string
+			set   bool
+		}{
+			{"write_only", t.WriteOnly},
+			{"url_param_only", t.UrlParamOnly},
+			{"ignore_read", t.IgnoreRead},
+		}...)
+	}
+	for _, u := range unsupported {
+		if u.set {
+			errs.Addf(u.field, "`%s` is not supported by plugin_framework in property %s of resource %s", u.field, t.Name, rName)
+		}
+	}
+	if t.ParentMetadata != nil && (t.IsA("KeyValueLabels") || t.IsA("KeyValueAnnotations")) {
+		errs.Addf("type", "Nested %s property %s is not supported by plugin_framework in resource %s, only root labels and annotations are", t.Type, t.Name, rName)
+	}
+	if t.Validation.Function != "" || t.ItemValidation.Function != "" {
+		errs.Addf("validation", "Validation functions are not supported by plugin_framework in property %s of resource %s, use a regex", t.Name, rName)
+	}
+	if t.DefaultValue != nil && !slices.Contains([]string{"types.String", "types.Int64", "types.Float64", "types.Bool"}, t.FrameworkType()) {
+		errs.Addf("default_value", "`default_value` is only supported on primitive properties by plugin_framework in property %s of resource %s", t.Name, rName)
+	}
+
+	for _, p := range t.NestedProperties() {
+		errs.Nest(fmt.Sprintf("properties[%s]", p.Name), p.validatePluginFramework(rName))
+	}
+	return errs
+}
+
 // TODO rewrite: validation
 // // Represents an enum, and store is valid values
 // class Enum < Primitive
@@ -1061,7 +1260,8 @@ func propertyWithApiName(apiName string) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+func (t *Type) validateLabelsField() google.ValidationErrors {
+	var errs google.ValidationErrors
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := t.Lineage()
@@ -1084,21 +1284,22 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Addf("type", "Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Addf("type", "Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Addf("type", "Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Addf("type", "Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
+	return errs
 }
 
 func (t Type) fieldMinVersion() string {
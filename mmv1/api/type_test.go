@@ -425,3 +425,99 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestFrameworkPathExpressions(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		BaseUrl: "test",
+		Properties: []*Type{
+			{Name: "name", Type: "String"},
+			{
+				Name: "config",
+				Type: "NestedObject",
+				Properties: []*Type{
+					{Name: "size", Type: "Integer"},
+					{Name: "rules", Type: "Array", ItemType: &Type{
+						Type:       "NestedObject",
+						Properties: []*Type{{Name: "expr", Type: "String"}},
+					}},
+				},
+			},
+		},
+	}
+	r.SetDefault(nil)
+
+	cases := []struct {
+		description string
+		paths       []string
+		expected    string
+	}{
+		{
+			description: "root",
+			paths:       []string{"name"},
+			expected:    `path.MatchRoot("name")`,
+		},
+		{
+			description: "nested object",
+			paths:       []string{"config.0.size"},
+			expected:    `path.MatchRoot("config").AtName("size")`,
+		},
+		{
+			description: "array item",
+			paths:       []string{"config.0.rules.0.expr"},
+			expected:    `path.MatchRoot("config").AtName("rules").AtListIndex(0).AtName("expr")`,
+		},
+		{
+			description: "several",
+			paths:       []string{"name", "config"},
+			expected:    `path.MatchRoot("name"), path.MatchRoot("config")`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := r.Properties[0].FrameworkPathExpressions(tc.paths)
+			if got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFrameworkWrite(t *testing.T) {
+	t.Parallel()
+
+	labeled := Resource{
+		BaseUrl: "test",
+		Properties: []*Type{
+			{
+				Name: "labels",
+				Type: "KeyValueLabels",
+			},
+			{
+				Name:   "createTime",
+				Type:   "String",
+				Output: true,
+			},
+		},
+	}
+	labeled.Properties = labeled.AddExtraFields(labeled.PropertiesWithExcluded(), nil, "")
+	labeled.SetDefault(nil)
+
+	expected := map[string]bool{
+		"labels":          false,
+		"createTime":      false,
+		"terraformLabels": false,
+		"effectiveLabels": true,
+	}
+	for _, p := range labeled.Properties {
+		if got := p.FrameworkWrite(); got != expected[p.Name] {
+			t.Errorf("expected FrameworkWrite of %s to be %t, got %t", p.Name, expected[p.Name], got)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance compares the fields of MMv1 resources with the schemas
+// of an OpenAPI or Discovery document of the API, to find the fields that
+// drifted from the API since the YAML was written.
+package conformance
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+// The kinds of findings
+const (
+	// The resource has no schema in the document
+	KindSchemaNotFound = "schema-not-found"
+	// A field of the API is not in the resource
+	KindMissingField = "missing-field"
+	// A field of the resource is not in the API
+	KindUnknownField = "unknown-field"
+	KindTypeMismatch = "type-mismatch"
+	KindOutput       = "output-mismatch"
+	KindImmutable    = "immutable-mismatch"
+	KindRequired     = "required-mismatch"
+	// An enum value of the resource is not in the API
+	KindUnknownEnumValue = "unknown-enum-value"
+	// An enum value of the API is not in the resource
+	KindMissingEnumValue = "missing-enum-value"
+)
+
+type Options struct {
+	// The product directory, for example "products/pubsub"
+	Product string
+	// The OpenAPI or Discovery document of the API of the product
+	Spec string
+	// The provider version whose fields are checked. Fields excluded at this
+	// version are not reported as missing.
+	Version string
+}
+
+// A difference between a resource and the API.
+type Finding struct {
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+	File     string `json:"file"`
+	// The dot separated API path of the field, e.g. schemaSettings.encoding
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Field == "" {
+		return fmt.Sprintf("%s: %s: [%s] %s", f.File, f.Resource, f.Kind, f.Message)
+	}
+	return fmt.Sprintf("%s: %s.%s: [%s] %s", f.File, f.Resource, f.Field, f.Kind, f.Message)
+}
+
+// Loads the resources of a product and compares them with the document.
+// Findings are sorted by file, then field.
+func Run(opts Options) ([]Finding, error) {
+	spec, err := LoadSpec(opts.Spec)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := loadResources(opts.Product, opts.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, r := range resources {
+		findings = append(findings, Check(r, spec)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Field < findings[j].Field
+	})
+	return findings, nil
+}
+
+// Loads the resources of a product that exist at version.
+func loadResources(productDir, version string) ([]*api.Resource, error) {
+	productApi := &api.Product{}
+	if err := api.Compile(filepath.Join(productDir, "product.yaml"), productApi, ""); err != nil {
+		return nil, err
+	}
+	if !productApi.ExistsAtVersionOrLower(version) {
+		return nil, fmt.Errorf("%s does not have a %q version", productDir, version)
+	}
+
+	resourceFiles, err := filepath.Glob(filepath.Join(productDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var resources []*api.Resource
+	for _, resourceYamlPath := range resourceFiles {
+		if filepath.Base(resourceYamlPath) == "product.yaml" {
+			continue
+		}
+		resource := &api.Resource{}
+		if err := api.Compile(resourceYamlPath, resource, ""); err != nil {
+			return nil, err
+		}
+		resource.SourceYamlFile = resourceYamlPath
+		resource.TargetVersionName = version
+		resource.SetDefault(productApi)
+		resources = append(resources, resource)
+	}
+
+	versionObj := productApi.VersionObjOrClosest(version)
+	var inVersion []*api.Resource
+	for _, r := range resources {
+		r.ExcludeIfNotInVersion(versionObj)
+		if !r.IsExcluded() {
+			inVersion = append(inVersion, r)
+		}
+	}
+	return inVersion, nil
+}
+
+// Compares the fields of a resource with its schema in spec.
+func Check(r *api.Resource, spec *Spec) []Finding {
+	kind := r.ApiResourceTypeKind
+	if kind == "" {
+		kind = r.Name
+	}
+	c := checker{resource: r}
+	schema := spec.Schema(kind)
+	if schema == nil {
+		c.add(KindSchemaNotFound, "", "no schema named %s in the document, set api_resource_type_kind if the API names it differently", kind)
+		return c.findings
+	}
+	c.checkFields(google.Concat(r.Parameters, r.Properties), schema, "")
+	return c.findings
+}
+
+type checker struct {
+	resource *api.Resource
+	findings []Finding
+}
+
+func (c *checker) add(kind, field, format string, a ...any) {
+	c.findings = append(c.findings, Finding{
+		Kind:     kind,
+		Resource: c.resource.Name,
+		File:     c.resource.SourceYamlFile,
+		Field:    field,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// Compares the fields of an object with the properties of its schema. prefix
+// is the API path of the object followed by a dot.
+func (c *checker) checkFields(fields []*api.Type, schema *Field, prefix string) {
+	if schema.Type == "" {
+		// Fields of any type can't be compared
+		return
+	}
+
+	// Fields excluded at this version, and fields of the URL, are known but
+	// not compared
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.ApiName] = true
+		if f.UrlParamOnly || f.Exclude || f.ClientSide {
+			continue
+		}
+		if f.IsA("KeyValueEffectiveLabels") || f.IsA("KeyValueTerraformLabels") {
+			continue
+		}
+		path := prefix + f.ApiName
+		apiField, ok := schema.Properties[f.ApiName]
+		if !ok {
+			c.add(KindUnknownField, path, "%s is not a field of the API", f.ApiName)
+			continue
+		}
+		c.checkField(f, apiField, path)
+	}
+
+	var missing []string
+	for name, apiField := range schema.Properties {
+		if !known[name] && !apiField.Recursive && !isStandardField(prefix, name, apiField) {
+			missing = append(missing, name)
+		}
+	}
+	slices.Sort(missing)
+	for _, name := range missing {
+		c.add(KindMissingField, prefix+name, "the API field %s is not in the resource", name)
+	}
+}
+
+// The fields of resources that are intentionally not in MMv1 resources
+func isStandardField(prefix, name string, f *Field) bool {
+	if prefix != "" {
+		return false
+	}
+	switch name {
+	case "etag", "selfLink", "kind", "id":
+		return f.Output
+	}
+	return false
+}
+
+func (c *checker) checkField(f *api.Type, apiField *Field, path string) {
+	if apiField.Type == "" {
+		return
+	}
+	if !compatibleType(f, apiField) {
+		c.add(KindTypeMismatch, path, "%s is a %s field, but the API type is %s", f.ApiName, f.Type, describeType(apiField))
+		return
+	}
+
+	if apiField.Output && !f.Output {
+		c.add(KindOutput, path, "%s is output only in the API, set output: true", f.ApiName)
+	} else if f.Output && !apiField.Output && !f.IsA("Fingerprint") {
+		c.add(KindOutput, path, "%s can be set in the API, but is output: true", f.ApiName)
+	}
+	if apiField.Immutable && !f.Output && !f.IsForceNew() {
+		c.add(KindImmutable, path, "%s is immutable in the API, set immutable: true", f.ApiName)
+	}
+	if apiField.Required && !f.Required && !f.Output && !apiField.Output {
+		c.add(KindRequired, path, "%s is required by the API, set required: true", f.ApiName)
+	} else if f.Required && !apiField.Required {
+		c.add(KindRequired, path, "%s is optional in the API, but is required: true", f.ApiName)
+	}
+
+	switch {
+	case f.IsA("Enum"):
+		c.checkEnumValues(f.EnumValues, apiField.EnumValues, path)
+	case f.IsA("NestedObject"):
+		c.checkFields(f.Properties, apiField, path+".")
+	case f.IsA("Array") && f.ItemType != nil && apiField.Items != nil:
+		switch {
+		case f.ItemType.IsA("Enum"):
+			c.checkEnumValues(f.ItemType.EnumValues, apiField.Items.EnumValues, path)
+		case f.ItemType.IsA("NestedObject"):
+			c.checkFields(f.ItemType.Properties, apiField.Items, path+".")
+		}
+	case f.IsA("Map") && f.ValueType != nil && apiField.Values != nil:
+		c.checkFields(f.ValueType.Properties, apiField.Values, path+".")
+	}
+}
+
+// Compares enum values only when the API lists them. The *_UNSPECIFIED
+// default is ignored on both sides.
+func (c *checker) checkEnumValues(values, apiValues []string, path string) {
+	if len(apiValues) == 0 {
+		return
+	}
+	var unknown, missing []string
+	for _, v := range values {
+		if !slices.Contains(apiValues, v) && !strings.HasSuffix(v, "_UNSPECIFIED") {
+			unknown = append(unknown, v)
+		}
+	}
+	for _, v := range apiValues {
+		if !slices.Contains(values, v) {
+			missing = append(missing, v)
+		}
+	}
+	if len(unknown) > 0 {
+		c.add(KindUnknownEnumValue, path, "values not in the API: %s", strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		c.add(KindMissingEnumValue, path, "API values not in the resource: %s", strings.Join(missing, ", "))
+	}
+}
+
+// Reports whether the MMv1 type of f can represent the API field.
+func compatibleType(f *api.Type, apiField *Field) bool {
+	switch apiField.Type {
+	case "string":
+		switch f.Type {
+		case "String", "Enum", "Time", "ResourceRef", "Fingerprint":
+			return true
+		case "Integer":
+			// 64-bit integers are strings in JSON
+			return apiField.Format == "int64" || apiField.Format == "uint64"
+		}
+	case "integer":
+		return f.Type == "Integer"
+	case "number":
+		return f.Type == "Double"
+	case "boolean":
+		return f.Type == "Boolean"
+	case "object":
+		return f.Type == "NestedObject"
+	case "map":
+		return f.Type == "Map" || strings.HasPrefix(f.Type, "KeyValue")
+	case "array":
+		return f.Type == "Array"
+	}
+	return false
+}
+
+func describeType(f *Field) string {
+	if f.Format != "" {
+		return fmt.Sprintf("%s (%s)", f.Type, f.Format)
+	}
+	return f.Type
+}
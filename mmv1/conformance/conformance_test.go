@@ -0,0 +1,216 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+const testOpenapiSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: v1
+paths: {}
+components:
+  schemas:
+    Instance:
+      type: object
+      required: [config]
+      properties:
+        name:
+          type: string
+          x-google-identifier: true
+        displayName:
+          type: string
+        nodeCount:
+          type: string
+          format: int64
+        createTime:
+          type: string
+          format: date-time
+          readOnly: true
+        network:
+          type: string
+          x-google-immutable: true
+        tier:
+          type: string
+          enum: [TIER_UNSPECIFIED, BASIC, STANDARD]
+        config:
+          $ref: '#/components/schemas/Config'
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+    Config:
+      type: object
+      properties:
+        enabled:
+          type: boolean
+        parent:
+          $ref: '#/components/schemas/Config'
+`
+
+const testDiscoverySpec = `{
+  "discoveryVersion": "v1",
+  "name": "test",
+  "version": "v1",
+  "schemas": {
+    "Instance": {
+      "id": "Instance",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "displayName": {"type": "string", "description": "Required. The display name."},
+        "state": {"type": "string", "description": "Output only. The state.", "enum": ["STATE_UNSPECIFIED", "READY"]},
+        "network": {"type": "string", "description": "Immutable. The network."}
+      }
+    }
+  }
+}`
+
+func writeSpec(t *testing.T, name, content string) *Spec {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("cannot load spec: %v", err)
+	}
+	return spec
+}
+
+func testResource(properties []*api.Type) *api.Resource {
+	r := &api.Resource{
+		Name:           "Instance",
+		SourceYamlFile: "products/test/Instance.yaml",
+		Parameters: []*api.Type{
+			{Name: "location", Type: "String", UrlParamOnly: true, Required: true},
+		},
+		Properties: properties,
+	}
+	r.SetDefault(&api.Product{Name: "Test"})
+	return r
+}
+
+func TestCheckOpenapi(t *testing.T) {
+	t.Parallel()
+
+	spec := writeSpec(t, "test_v1.yaml", testOpenapiSpec)
+
+	cases := []struct {
+		description string
+		properties  []*api.Type
+		expected    []Finding
+	}{
+		{
+			description: "conforming resource",
+			properties: []*api.Type{
+				{Name: "name", Type: "String", Output: true},
+				{Name: "displayName", Type: "String"},
+				{Name: "nodeCount", Type: "Integer"},
+				{Name: "createTime", Type: "Time", Output: true},
+				{Name: "network", Type: "String", Immutable: true},
+				{Name: "tier", Type: "Enum", EnumValues: []string{"BASIC", "STANDARD"}},
+				{Name: "config", Type: "NestedObject", Required: true, Properties: []*api.Type{
+					{Name: "enabled", Type: "Boolean"},
+					{Name: "parent", Type: "NestedObject", Properties: []*api.Type{
+						{Name: "enabled", Type: "Boolean"},
+					}},
+				}},
+				{Name: "labels", Type: "KeyValueLabels"},
+			},
+		},
+		{
+			description: "renamed field with api_name and excluded fields",
+			properties: []*api.Type{
+				{Name: "name", Type: "String", Output: true},
+				{Name: "title", ApiName: "displayName", Type: "String"},
+				{Name: "nodeCount", Type: "Integer", Exclude: true},
+				{Name: "createTime", Type: "Time", Output: true},
+				{Name: "network", Type: "String", Immutable: true},
+				{Name: "tier", Type: "Enum", Exclude: true},
+				{Name: "config", Type: "NestedObject", Required: true, Exclude: true},
+				{Name: "labels", Type: "KeyValueLabels", Exclude: true},
+			},
+		},
+		{
+			description: "drifted resource",
+			properties: []*api.Type{
+				{Name: "name", Type: "String", Output: true},
+				{Name: "displayName", Type: "Integer"},
+				{Name: "createTime", Type: "Time"},
+				{Name: "network", Type: "String"},
+				{Name: "tier", Type: "Enum", EnumValues: []string{"BASIC", "PREMIUM"}},
+				{Name: "config", Type: "NestedObject", Properties: []*api.Type{
+					{Name: "enabled", Type: "Boolean", Required: true},
+				}},
+				{Name: "labels", Type: "KeyValueLabels"},
+				{Name: "removed", Type: "String"},
+			},
+			expected: []Finding{
+				{Kind: KindTypeMismatch, Field: "displayName", Message: "displayName is a Integer field, but the API type is string"},
+				{Kind: KindOutput, Field: "createTime", Message: "createTime is output only in the API, set output: true"},
+				{Kind: KindImmutable, Field: "network", Message: "network is immutable in the API, set immutable: true"},
+				{Kind: KindUnknownEnumValue, Field: "tier", Message: "values not in the API: PREMIUM"},
+				{Kind: KindMissingEnumValue, Field: "tier", Message: "API values not in the resource: STANDARD"},
+				{Kind: KindRequired, Field: "config", Message: "config is required by the API, set required: true"},
+				{Kind: KindRequired, Field: "config.enabled", Message: "enabled is optional in the API, but is required: true"},
+				{Kind: KindMissingField, Field: "config.parent", Message: "the API field parent is not in the resource"},
+				{Kind: KindUnknownField, Field: "removed", Message: "removed is not a field of the API"},
+				{Kind: KindMissingField, Field: "nodeCount", Message: "the API field nodeCount is not in the resource"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			for i := range tc.expected {
+				tc.expected[i].Resource = "Instance"
+				tc.expected[i].File = "products/test/Instance.yaml"
+			}
+			got := Check(testResource(tc.properties), spec)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckDiscovery(t *testing.T) {
+	t.Parallel()
+
+	spec := writeSpec(t, "test_v1.json", testDiscoverySpec)
+	r := testResource([]*api.Type{
+		{Name: "name", Type: "String", Required: true, Immutable: true},
+		{Name: "displayName", Type: "String"},
+		{Name: "state", Type: "Enum", Output: true, EnumValues: []string{"READY"}},
+	})
+
+	expected := []Finding{
+		{Kind: KindRequired, Field: "name", Message: "name is optional in the API, but is required: true"},
+		{Kind: KindRequired, Field: "displayName", Message: "displayName is required by the API, set required: true"},
+		{Kind: KindMissingField, Field: "network", Message: "the API field network is not in the resource"},
+	}
+	for i := range expected {
+		expected[i].Resource = "Instance"
+		expected[i].File = "products/test/Instance.yaml"
+	}
+	if got := Check(r, spec); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	r.ApiResourceTypeKind = "Cluster"
+	if got := Check(r, spec); len(got) != 1 || got[0].Kind != KindSchemaNotFound {
+		t.Errorf("expected a %s finding, got %v", KindSchemaNotFound, got)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Writes findings in the given format: text or json.
+func Write(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case "text":
+		return writeText(w, findings)
+	case "json":
+		return writeJSON(w, findings)
+	}
+	return fmt.Errorf("unknown conformance output format %q, expected one of text, json", format)
+}
+
+func writeText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintln(w, f.String()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d finding(s)\n", len(findings))
+	return err
+}
+
+func writeJSON(w io.Writer, findings []Finding) error {
+	out := struct {
+		Findings []Finding `json:"findings"`
+	}{Findings: findings}
+	if out.Findings == nil {
+		out.Findings = []Finding{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
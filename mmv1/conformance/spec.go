@@ -0,0 +1,226 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/discovery_generate"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/importer"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
+	"github.com/getkin/kin-openapi/openapi3"
+	"golang.org/x/exp/slices"
+)
+
+// A field of the API, read from an OpenAPI or Discovery document.
+type Field struct {
+	// The JSON type: string, integer, number, boolean, object, map or array.
+	// Empty for fields of any type, such as google.protobuf.Value.
+	Type string
+	// The format of strings and numbers, for example int64 or date-time
+	Format string
+	// The enum values, without the *_UNSPECIFIED default
+	EnumValues []string
+
+	Output    bool
+	Immutable bool
+	Required  bool
+	// The schema of the field is one of its ancestors, and was not expanded
+	// again
+	Recursive bool
+
+	// The fields of an object, by API name
+	Properties map[string]*Field
+	// The items of an array
+	Items *Field
+	// The values of a map
+	Values *Field
+}
+
+// The schemas of an OpenAPI or Discovery document.
+type Spec struct {
+	openapi   *openapi3.T
+	discovery *discovery_generate.Document
+}
+
+// Loads an OpenAPI document, in YAML or JSON, or a Discovery document. The
+// format is detected from the content.
+func LoadSpec(path string) (*Spec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovery struct {
+		DiscoveryVersion string `json:"discoveryVersion"`
+	}
+	if json.Unmarshal(content, &discovery) == nil && discovery.DiscoveryVersion != "" {
+		doc := &discovery_generate.Document{}
+		if err := json.Unmarshal(content, doc); err != nil {
+			return nil, fmt.Errorf("cannot parse Discovery document %s: %v", path, err)
+		}
+		return &Spec{discovery: doc}, nil
+	}
+
+	loader := &openapi3.Loader{Context: context.Background(), IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse OpenAPI document %s: %v", path, err)
+	}
+	return &Spec{openapi: doc}, nil
+}
+
+// Returns the schema called name, or nil if the document has none. The name
+// of the schema of a resource is its api_resource_type_kind, or its name by
+// default.
+func (s *Spec) Schema(name string) *Field {
+	switch {
+	case s.openapi != nil:
+		if s.openapi.Components == nil || s.openapi.Components.Schemas[name] == nil {
+			return nil
+		}
+		b := openapiBuilder{recursion: importer.NewRecursionGuard[*openapi3.Schema]()}
+		return b.field(s.openapi.Components.Schemas[name])
+	case s.discovery != nil:
+		if s.discovery.Schemas[name] == nil {
+			return nil
+		}
+		b := discoveryBuilder{doc: s.discovery, recursion: importer.NewRecursionGuard[string]()}
+		return b.field(&discovery_generate.Schema{Ref: name})
+	}
+	return nil
+}
+
+// Recursive schemas are expanded importer.MaxRecursionDepth times, and then
+// treated as untyped.
+type openapiBuilder struct {
+	recursion importer.RecursionGuard[*openapi3.Schema]
+}
+
+func (b openapiBuilder) field(ref *openapi3.SchemaRef) *Field {
+	v := openapi_generate.ResolveSchema(ref)
+	if v == nil {
+		return nil
+	}
+	f := &Field{
+		Type:       openapi_generate.SchemaType(v),
+		Format:     v.Format,
+		EnumValues: importer.EnumValues(v.Enum),
+		Output:     v.ReadOnly,
+	}
+	// x-google-identifier fields are described by AIP 203 and are output only
+	// in Terraform
+	if id, err := ref.JSONLookup("x-google-identifier"); err == nil && id != nil {
+		f.Output = true
+	}
+	if immutable, err := ref.JSONLookup("x-google-immutable"); err == nil && immutable != nil {
+		f.Immutable = true
+	}
+
+	if !b.recursion.Enter(v) {
+		f.Type = ""
+		f.Recursive = true
+		return f
+	}
+	defer b.recursion.Leave(v)
+
+	switch f.Type {
+	case openapi3.TypeObject:
+		if v.AdditionalProperties.Schema != nil {
+			f.Type = "map"
+			f.Values = b.field(v.AdditionalProperties.Schema)
+			break
+		}
+		f.Properties = map[string]*Field{}
+		props := openapi3.Schemas{}
+		for name, p := range v.Properties {
+			props[name] = p
+		}
+		for _, alternatives := range []openapi3.SchemaRefs{v.OneOf, v.AnyOf} {
+			for _, alt := range alternatives {
+				if av := openapi_generate.ResolveSchema(alt); av != nil {
+					for name, p := range av.Properties {
+						props[name] = p
+					}
+				}
+			}
+		}
+		for name, p := range props {
+			if child := b.field(p); child != nil {
+				child.Required = child.Required || slices.Contains(v.Required, name)
+				f.Properties[name] = child
+			}
+		}
+	case openapi3.TypeArray:
+		f.Items = b.field(v.Items)
+	}
+	return f
+}
+
+// Converts Discovery schemas, with the same handling of recursion as
+// openapiBuilder.
+type discoveryBuilder struct {
+	doc       *discovery_generate.Document
+	recursion importer.RecursionGuard[string]
+}
+
+func (b discoveryBuilder) field(s *discovery_generate.Schema) *Field {
+	// Discovery documents describe these flags in the description
+	description := strings.TrimSpace(s.Description)
+	f := &Field{
+		Output:    s.ReadOnly || strings.HasPrefix(description, "Output only."),
+		Immutable: strings.HasPrefix(description, "Immutable."),
+		Required:  s.Required || strings.HasPrefix(description, "Required."),
+	}
+
+	if s.Ref != "" {
+		ref := b.doc.Schemas[s.Ref]
+		if ref == nil {
+			return f
+		}
+		if !b.recursion.Enter(s.Ref) {
+			f.Recursive = true
+			return f
+		}
+		defer b.recursion.Leave(s.Ref)
+		s = ref
+	}
+
+	f.Type = s.Type
+	f.Format = s.Format
+	f.EnumValues = importer.EnumValues(s.Enum)
+	switch s.Type {
+	case "object":
+		if s.AdditionalProperties != nil {
+			f.Type = "map"
+			f.Values = b.field(s.AdditionalProperties)
+			break
+		}
+		f.Properties = map[string]*Field{}
+		for name, p := range s.Properties {
+			f.Properties[name] = b.field(p)
+		}
+	case "array":
+		if s.Items != nil {
+			f.Items = b.field(s.Items)
+		}
+	case "any":
+		f.Type = ""
+	}
+	return f
+}
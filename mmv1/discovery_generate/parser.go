@@ -0,0 +1,479 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Generates MMv1 YAML from a Google API Discovery document.
+// https://developers.google.com/discovery/v1/reference/apis
+
+package discovery_generate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/importer"
+	"gopkg.in/yaml.v2"
+)
+
+// The subset of a Discovery document used to generate resources
+type Document struct {
+	Name        string
+	Version     string
+	Title       string
+	RootUrl     string
+	ServicePath string
+	Auth        struct {
+		Oauth2 struct {
+			Scopes map[string]any
+		}
+	}
+	Schemas   map[string]*Schema
+	Resources map[string]*DiscoveryResource
+}
+
+type DiscoveryResource struct {
+	Methods   map[string]*Method
+	Resources map[string]*DiscoveryResource
+}
+
+type Method struct {
+	Id         string
+	Path       string
+	FlatPath   string
+	HttpMethod string
+	Parameters map[string]*Schema
+	Request    *Schema
+	Response   *Schema
+}
+
+type Schema struct {
+	Id                   string
+	Ref                  string `json:"$ref"`
+	Type                 string
+	Format               string
+	Description          string
+	Location             string
+	Required             bool
+	ReadOnly             bool
+	Enum                 []string
+	Properties           map[string]*Schema
+	AdditionalProperties *Schema
+	Items                *Schema
+}
+
+type Parser struct {
+	// Path to the Discovery document
+	File   string
+	Output string
+}
+
+func NewDiscoveryParser(file, output string) Parser {
+	return Parser{File: file, Output: output}
+}
+
+func (parser Parser) Run() {
+	log.Printf("Reading from file path %s", parser.File)
+	content, err := os.ReadFile(parser.File)
+	if err != nil {
+		log.Fatalf("error reading discovery document %v", err)
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		log.Fatalf("error parsing discovery document %v: %v", parser.File, err)
+	}
+
+	productPath := filepath.Join(parser.Output, doc.Name)
+	if err := os.MkdirAll(productPath, os.ModePerm); err != nil {
+		log.Fatalf("error creating product output directory %v: %v", productPath, err)
+	}
+
+	// Disables line wrap for long strings
+	yaml.FutureLineWrap()
+
+	writeYaml(filepath.Join(productPath, "product.yaml"), buildProduct(doc), importer.Header)
+	for _, resource := range buildResources(doc) {
+		writeYaml(filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name)), resource, importer.Header)
+	}
+}
+
+func writeYaml(path string, obj any, header []byte) {
+	bytes, err := yaml.Marshal(obj)
+	if err != nil {
+		log.Fatalf("error marshalling yaml %v: %v", path, err)
+	}
+	if err := os.WriteFile(path, append(header, bytes...), 0644); err != nil {
+		log.Fatalf("error writing %v: %v", path, err)
+	}
+	log.Printf("Generated %s", path)
+}
+
+func buildProduct(doc *Document) *api.Product {
+	apiProduct := &api.Product{}
+	apiVersion := &product.Version{}
+
+	apiVersion.BaseUrl = fmt.Sprintf("%s%s%s/", doc.RootUrl, doc.ServicePath, doc.Version)
+	// TODO figure out how to tell the API version
+	apiVersion.Name = "ga"
+	apiProduct.Versions = []*product.Version{apiVersion}
+
+	// Standard titling is "Service Name API"
+	displayName := strings.Replace(doc.Title, " API", "", 1)
+	apiProduct.Name = strings.ReplaceAll(displayName, " ", "")
+	apiProduct.DisplayName = displayName
+
+	scope := "https://www.googleapis.com/auth/cloud-platform"
+	if _, ok := doc.Auth.Oauth2.Scopes[scope]; !ok && len(doc.Auth.Oauth2.Scopes) > 0 {
+		scope = slices.Sorted(maps.Keys(doc.Auth.Oauth2.Scopes))[0]
+	}
+	apiProduct.Scopes = []string{scope}
+	return apiProduct
+}
+
+// Returns a resource for every collection in the document with create and
+// get methods, in name order.
+func buildResources(doc *Document) []api.Resource {
+	var resources []api.Resource
+	var walk func(map[string]*DiscoveryResource)
+	walk = func(collections map[string]*DiscoveryResource) {
+		for _, name := range slices.Sorted(maps.Keys(collections)) {
+			c := collections[name]
+			if resource, ok := buildResource(doc, c); ok {
+				resources = append(resources, resource)
+			}
+			walk(c.Resources)
+		}
+	}
+	walk(doc.Resources)
+	slices.SortFunc(resources, func(a, b api.Resource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return resources
+}
+
+var pathParamRegex = regexp.MustCompile(`\{\+?(\w+)\}`)
+
+func buildResource(doc *Document, c *DiscoveryResource) (api.Resource, bool) {
+	resource := api.Resource{}
+	create := c.Methods["create"]
+	get := c.Methods["get"]
+	if create == nil || get == nil || create.Request == nil || create.Request.Ref == "" {
+		return resource, false
+	}
+	resourceName := create.Request.Ref
+	schema := doc.Schemas[resourceName]
+	if schema == nil {
+		log.Printf("%s: schema not found, skipping", resourceName)
+		return resource, false
+	}
+
+	// The last segment of the get path is the ID of the resource, and the
+	// other path parameters are its parents
+	selfLinkSegments := strings.Split(stripVersion(doc, flatPath(get)), "/")
+	parameters := []*api.Type{}
+	for i, segment := range selfLinkSegments {
+		m := pathParamRegex.FindStringSubmatch(segment)
+		if m == nil {
+			continue
+		}
+		name := pathParamName(selfLinkSegments, i)
+		if i == len(selfLinkSegments)-1 {
+			name = "name"
+		}
+		selfLinkSegments[i] = fmt.Sprintf("{{%s}}", google.Underscore(name))
+		if i == len(selfLinkSegments)-1 || name == "project" {
+			continue
+		}
+		parameters = append(parameters, &api.Type{
+			Name:         name,
+			Type:         "String",
+			Description:  fmt.Sprintf("The %s of the %s.", strings.ToLower(google.SpaceSeparated(name)), strings.ToLower(google.SpaceSeparated(resourceName))),
+			UrlParamOnly: true,
+			Required:     true,
+			Immutable:    true,
+		})
+	}
+	selfLink := strings.Join(selfLinkSegments, "/")
+	baseUrl := strings.Join(selfLinkSegments[:len(selfLinkSegments)-1], "/")
+
+	resource.Name = resourceName
+	resource.Description = importer.TrimDescription(schema.Description)
+	if resource.Description == "" {
+		resource.Description = "Description"
+	}
+	resource.BaseUrl = baseUrl
+	resource.SelfLink = selfLink
+	resource.IdFormat = selfLink
+	resource.ImportFormat = []string{selfLink}
+
+	switch create.HttpMethod {
+	case "POST":
+		// The ID of the new resource is a query parameter such as instanceId
+		idParam := ""
+		for _, name := range slices.Sorted(maps.Keys(create.Parameters)) {
+			p := create.Parameters[name]
+			if p.Location == "query" && strings.EqualFold(name, resourceName+"Id") {
+				idParam = name
+			}
+		}
+		if idParam == "" {
+			// The name is assigned by the server
+			resource.CreateUrl = baseUrl
+		} else {
+			selfLink = strings.Replace(selfLink, "{{name}}", fmt.Sprintf("{{%s}}", google.Underscore(idParam)), 1)
+			resource.SelfLink = selfLink
+			resource.IdFormat = selfLink
+			resource.ImportFormat = []string{selfLink}
+			resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, idParam, google.Underscore(idParam))
+			parameters = append(parameters, &api.Type{
+				Name:         idParam,
+				Type:         "String",
+				Description:  importer.TrimDescription(create.Parameters[idParam].Description),
+				UrlParamOnly: true,
+				Required:     true,
+				Immutable:    true,
+			})
+		}
+	default:
+		// The resource is created at its own URL, as with PUT
+		resource.CreateVerb = create.HttpMethod
+		resource.CreateUrl = selfLink
+	}
+
+	if patch := c.Methods["patch"]; patch != nil {
+		resource.UpdateVerb = patch.HttpMethod
+		if _, ok := patch.Parameters["updateMask"]; ok {
+			resource.UpdateMask = true
+		}
+	} else if update := c.Methods["update"]; update != nil {
+		resource.UpdateVerb = update.HttpMethod
+	} else {
+		resource.Immutable = true
+	}
+
+	if c.Methods["delete"] == nil {
+		resource.ExcludeDelete = true
+	}
+
+	var actions []string
+	for _, action := range []string{"create", "delete", "update"} {
+		method := c.Methods[action]
+		if action == "update" && c.Methods["patch"] != nil {
+			method = c.Methods["patch"]
+		}
+		if method != nil && method.Response != nil && method.Response.Ref == "Operation" {
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) > 0 {
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = actions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = true
+		resource.Async = async
+	}
+
+	b := &typeBuilder{doc: doc, recursion: importer.NewRecursionGuard[string]()}
+	resource.Parameters = parameters
+	resource.Properties = b.buildProperties(schema)
+
+	example := r.Examples{}
+	example.Name = "name_of_example_file"
+	example.PrimaryResourceId = "example"
+	example.Vars = map[string]string{"resource_name": "test-resource"}
+
+	resource.Examples = []r.Examples{example}
+
+	// Write the status as an encoded string to flag when a YAML file has been
+	// copy and pasted without actually using this tool
+	resource.AutogenStatus = base64.StdEncoding.EncodeToString([]byte(resourceName))
+
+	return resource, true
+}
+
+// Returns the flat path of a method, which has one parameter per segment
+// rather than a single {+name} parameter.
+func flatPath(m *Method) string {
+	if m.FlatPath != "" {
+		return m.FlatPath
+	}
+	return m.Path
+}
+
+// Discovery paths are prefixed with the version of the API, which already
+// exists in the product. Strip it out here
+func stripVersion(doc *Document, path string) string {
+	return strings.TrimPrefix(path, doc.Version+"/")
+}
+
+// Names a path parameter after the collection before it, for example
+// {locationsId} in locations/{locationsId} is location.
+func pathParamName(segments []string, i int) string {
+	if i == 0 || pathParamRegex.MatchString(segments[i-1]) {
+		return pathParamRegex.FindStringSubmatch(segments[i])[1]
+	}
+	collection := segments[i-1]
+	switch {
+	case strings.HasSuffix(collection, "ies"):
+		return strings.TrimSuffix(collection, "ies") + "y"
+	case strings.HasSuffix(collection, "sses"):
+		return strings.TrimSuffix(collection, "es")
+	}
+	return strings.TrimSuffix(collection, "s")
+}
+
+// Converts Discovery schemas to MMv1 types.
+type typeBuilder struct {
+	doc *Document
+	// Breaks recursive references, by schema name
+	recursion importer.RecursionGuard[string]
+}
+
+func (b *typeBuilder) buildProperties(schema *Schema) []*api.Type {
+	properties := []*api.Type{}
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if field, ok := b.buildField(name, schema.Properties[name]); ok {
+			properties = append(properties, field)
+		}
+	}
+	return properties
+}
+
+// Builds the field called name. Fields that cannot be represented in MMv1
+// are logged and left out.
+func (b *typeBuilder) buildField(name string, s *Schema) (*api.Type, bool) {
+	field := &api.Type{Name: name}
+	if !b.setType(field, s) {
+		return nil, false
+	}
+
+	field.Description = importer.TrimDescription(s.Description)
+	if field.Description == "" {
+		field.Description = "No description"
+	}
+	description := strings.TrimSpace(s.Description)
+	if s.ReadOnly || strings.HasPrefix(description, "Output only.") {
+		field.Output = true
+	}
+	if strings.HasPrefix(description, "Required.") || s.Required {
+		field.Required = true
+	}
+	if strings.HasPrefix(description, "Immutable.") {
+		field.Immutable = true
+	}
+	return field, true
+}
+
+// Sets the type of field from s, resolving $ref. Returns false if the schema
+// cannot be represented.
+func (b *typeBuilder) setType(field *api.Type, s *Schema) bool {
+	if s.Ref != "" {
+		ref := b.doc.Schemas[s.Ref]
+		if ref == nil {
+			log.Printf("Skipping %s: schema %s not found", field.Name, s.Ref)
+			return false
+		}
+		if !b.recursion.Enter(s.Ref) {
+			log.Printf("Warning: skipping %s: recursive schema %s truncated after %d levels", field.Name, s.Ref, importer.MaxRecursionDepth)
+			return false
+		}
+		defer b.recursion.Leave(s.Ref)
+		s = ref
+	}
+
+	switch s.Type {
+	case "string":
+		field.Type = "String"
+		switch s.Format {
+		case "int64", "int32":
+			field.Type = "Integer"
+		case "google-datetime", "date-time":
+			field.Type = "Time"
+		}
+		if enums := importer.EnumValues(s.Enum); len(enums) > 0 {
+			field.Type = "Enum"
+			field.EnumValues = enums
+		}
+	case "integer":
+		field.Type = "Integer"
+	case "number":
+		field.Type = "Double"
+	case "boolean":
+		field.Type = "Boolean"
+	case "object":
+		if field.Name == "labels" {
+			// Standard labels implementation
+			field.Type = "KeyValueLabels"
+			return true
+		}
+		if s.AdditionalProperties != nil {
+			value := &api.Type{Name: strings.TrimSuffix(field.Name, "s")}
+			if !b.setType(value, s.AdditionalProperties) {
+				return false
+			}
+			switch value.Type {
+			case "String":
+				field.Type = "KeyValuePairs"
+			case "NestedObject":
+				field.Type = "Map"
+				field.KeyName = "name"
+				field.KeyDescription = "The key of the map entry."
+				field.ValueType = value
+			default:
+				log.Printf("Skipping %s: maps of %s are not supported", field.Name, value.Type)
+				return false
+			}
+			return true
+		}
+		if len(s.Properties) == 0 {
+			log.Printf("Skipping %s: object without properties", field.Name)
+			return false
+		}
+		field.Type = "NestedObject"
+		field.Properties = b.buildProperties(s)
+		if len(field.Properties) == 0 {
+			log.Printf("Skipping %s: none of its properties could be mapped", field.Name)
+			return false
+		}
+	case "array":
+		if s.Items == nil {
+			log.Printf("Skipping %s: array without items", field.Name)
+			return false
+		}
+		item := &api.Type{}
+		if !b.setType(item, s.Items) {
+			return false
+		}
+		if item.Type == "Array" {
+			log.Printf("Skipping %s: arrays of arrays are not supported", field.Name)
+			return false
+		}
+		field.Type = "Array"
+		field.ItemType = item
+	default:
+		log.Printf("Skipping %s: unknown type %q", field.Name, s.Type)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package discovery_generate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"golang.org/x/exp/slices"
+)
+
+const testDocument = `{
+  "name": "test",
+  "version": "v1",
+  "title": "Test Service API",
+  "rootUrl": "https://test.googleapis.com/",
+  "servicePath": "",
+  "auth": {"oauth2": {"scopes": {"https://www.googleapis.com/auth/cloud-platform": {}}}},
+  "schemas": {
+    "Instance": {
+      "id": "Instance",
+      "type": "object",
+      "description": "An instance.",
+      "properties": {
+        "name": {"type": "string", "description": "Output only. The resource name."},
+        "tier": {"type": "string", "enum": ["TIER_UNSPECIFIED", "BASIC", "PREMIUM"]},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "sizeGb": {"type": "string", "format": "int64", "description": "Required. The size."},
+        "createTime": {"type": "string", "format": "google-datetime", "readOnly": true},
+        "network": {"$ref": "Network", "description": "Immutable. The network."},
+        "zones": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "Network": {
+      "id": "Network",
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "parent": {"$ref": "Network"}
+      }
+    },
+    "Topic": {
+      "id": "Topic",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    },
+    "Operation": {"id": "Operation", "type": "object"}
+  },
+  "resources": {
+    "projects": {
+      "resources": {
+        "locations": {
+          "resources": {
+            "instances": {
+              "methods": {
+                "create": {
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/instances",
+                  "httpMethod": "POST",
+                  "parameters": {
+                    "parent": {"location": "path", "type": "string"},
+                    "instanceId": {"location": "query", "type": "string", "description": "Required. The ID of the instance."}
+                  },
+                  "request": {"$ref": "Instance"},
+                  "response": {"$ref": "Operation"}
+                },
+                "get": {
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}",
+                  "httpMethod": "GET",
+                  "response": {"$ref": "Instance"}
+                },
+                "patch": {
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}",
+                  "httpMethod": "PATCH",
+                  "parameters": {"updateMask": {"location": "query", "type": "string"}},
+                  "response": {"$ref": "Operation"}
+                },
+                "delete": {
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}",
+                  "httpMethod": "DELETE",
+                  "response": {"$ref": "Operation"}
+                }
+              }
+            }
+          }
+        },
+        "topics": {
+          "methods": {
+            "create": {
+              "flatPath": "v1/projects/{projectsId}/topics/{topicsId}",
+              "httpMethod": "PUT",
+              "request": {"$ref": "Topic"},
+              "response": {"$ref": "Topic"}
+            },
+            "get": {
+              "flatPath": "v1/projects/{projectsId}/topics/{topicsId}",
+              "httpMethod": "GET",
+              "response": {"$ref": "Topic"}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func loadTestDocument(t *testing.T) *Document {
+	t.Helper()
+	doc := &Document{}
+	if err := json.Unmarshal([]byte(testDocument), doc); err != nil {
+		t.Fatalf("cannot parse test document: %v", err)
+	}
+	return doc
+}
+
+func TestBuildProduct(t *testing.T) {
+	t.Parallel()
+
+	p := buildProduct(loadTestDocument(t))
+	if p.Name != "TestService" || p.Versions[0].BaseUrl != "https://test.googleapis.com/v1/" {
+		t.Errorf("unexpected product %#v, base url %q", p, p.Versions[0].BaseUrl)
+	}
+}
+
+func TestBuildResources(t *testing.T) {
+	t.Parallel()
+
+	resources := buildResources(loadTestDocument(t))
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(resources))
+	}
+	instance, topic := resources[0], resources[1]
+
+	if want := "projects/{{project}}/locations/{{location}}/instances/{{instance_id}}"; instance.SelfLink != want {
+		t.Errorf("expected self_link %q, got %q", want, instance.SelfLink)
+	}
+	if want := "projects/{{project}}/locations/{{location}}/instances?instanceId={{instance_id}}"; instance.CreateUrl != want {
+		t.Errorf("expected create_url %q, got %q", want, instance.CreateUrl)
+	}
+	var params []string
+	for _, p := range instance.Parameters {
+		params = append(params, p.Name)
+	}
+	if !slices.Equal(params, []string{"location", "instanceId"}) {
+		t.Errorf("unexpected parameters %v", params)
+	}
+	if instance.Async == nil || !slices.Equal(instance.Async.Actions, []string{"create", "delete", "update"}) {
+		t.Errorf("expected Instance to be async, got %#v", instance.Async)
+	}
+	if instance.UpdateVerb != "PATCH" || !instance.UpdateMask {
+		t.Errorf("expected PATCH with update mask, got %q %v", instance.UpdateVerb, instance.UpdateMask)
+	}
+
+	properties := map[string]*api.Type{}
+	for _, p := range instance.Properties {
+		properties[p.Name] = p
+	}
+	cases := []struct {
+		description string
+		name        string
+		check       func(p *api.Type) bool
+	}{
+		{
+			description: "output only description",
+			name:        "name",
+			check:       func(p *api.Type) bool { return p.Output && p.Description == "The resource name." },
+		},
+		{
+			description: "enum values",
+			name:        "tier",
+			check: func(p *api.Type) bool {
+				return p.Type == "Enum" && slices.Equal(p.EnumValues, []string{"BASIC", "PREMIUM"})
+			},
+		},
+		{
+			description: "labels",
+			name:        "labels",
+			check:       func(p *api.Type) bool { return p.Type == "KeyValueLabels" },
+		},
+		{
+			description: "int64 and required",
+			name:        "sizeGb",
+			check:       func(p *api.Type) bool { return p.Type == "Integer" && p.Required },
+		},
+		{
+			description: "read only timestamp",
+			name:        "createTime",
+			check:       func(p *api.Type) bool { return p.Type == "Time" && p.Output },
+		},
+		{
+			description: "recursive ref",
+			name:        "network",
+			check: func(p *api.Type) bool {
+				parent := p.Properties[1]
+				return p.Immutable && p.Type == "NestedObject" && len(p.Properties) == 2 && parent.Name == "parent" && len(parent.Properties) == 1
+			},
+		},
+		{
+			description: "array",
+			name:        "zones",
+			check:       func(p *api.Type) bool { return p.Type == "Array" && p.ItemType.Type == "String" },
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok := properties[tc.name]
+			if !ok {
+				t.Fatalf("property %s was not generated", tc.name)
+			}
+			if !tc.check(p) {
+				t.Errorf("unexpected property %#v", p)
+			}
+		})
+	}
+
+	if want := "projects/{{project}}/topics/{{name}}"; topic.SelfLink != want || topic.CreateVerb != "PUT" || topic.CreateUrl != want {
+		t.Errorf("expected Topic to be created with PUT at %q, got %s %q", want, topic.CreateVerb, topic.CreateUrl)
+	}
+	if topic.Async != nil || !topic.Immutable || !topic.ExcludeDelete {
+		t.Errorf("expected synchronous immutable Topic without delete, got %#v", topic)
+	}
+}
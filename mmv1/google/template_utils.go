@@ -21,8 +21,6 @@ import (
 	"strings"
 
 	"text/template"
-
-	"github.com/golang/glog"
 )
 
 // Build a map(map[string]interface{}) from a list of paramerter
@@ -108,12 +106,13 @@ func TrimTemplate(templatePath string, e any) string {
 
 	tmpl, err := template.New(templateFileName).Funcs(templateFunctions).ParseFiles(templates...)
 	if err != nil {
-		glog.Exit(err)
+		// Templates calling this return the panic as an error
+		panic(err)
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, e); err != nil {
-		glog.Exit(err)
+		panic(err)
 	}
 
 	rs := contents.String()
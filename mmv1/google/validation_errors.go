@@ -0,0 +1,359 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// A single problem found while loading or validating a YAML file.
+type ValidationError struct {
+	// Path of the YAML file the problem was found in
+	File string `json:"file,omitempty"`
+
+	// 1-based position of the offending key, 0 if unknown
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// Key path of the offending value within the file, for example
+	// `properties[displayName].item_type`. List elements are addressed by
+	// their `name` key when they have one, and by index otherwise.
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		if e.Line > 0 {
+			sb.WriteString(fmt.Sprintf(":%d", e.Line))
+			if e.Column > 0 {
+				sb.WriteString(fmt.Sprintf(":%d", e.Column))
+			}
+		}
+		sb.WriteString(": ")
+	}
+	if e.Path != "" {
+		sb.WriteString(e.Path)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.Message)
+	return sb.String()
+}
+
+// A list of validation problems. Validation functions append to it instead
+// of exiting so that every problem in a run can be reported at once.
+type ValidationErrors []*ValidationError
+
+// Adds an error at the given key path, relative to the object being validated.
+func (errs *ValidationErrors) Addf(path, format string, a ...any) {
+	*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+// Appends the errors of a child object, prefixing their paths with the key
+// path of the child.
+func (errs *ValidationErrors) Nest(prefix string, children ValidationErrors) {
+	for _, e := range children {
+		switch {
+		case e.Path == "":
+			e.Path = prefix
+		case strings.HasPrefix(e.Path, "["):
+			e.Path = prefix + e.Path
+		case prefix != "":
+			e.Path = fmt.Sprintf("%s.%s", prefix, e.Path)
+		}
+		*errs = append(*errs, e)
+	}
+}
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Returns nil for an empty list so callers can use the usual `err != nil` check.
+func (errs ValidationErrors) ErrorOrNil() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Attributes the errors to a file and resolves their key paths to a line and
+// column within it. Resolution is best-effort: paths that point at fields
+// added by the generator keep the position of their closest parent.
+func (errs ValidationErrors) InFile(yamlPath string) ValidationErrors {
+	content, _ := os.ReadFile(yamlPath)
+	return errs.inContent(yamlPath, content)
+}
+
+// Returns the 1-based line and column of the key path within content, or
+// zeros if content cannot be parsed or no part of the path exists.
+func PositionForPath(content []byte, path string) (int, int) {
+	var root yamlv3.Node
+	if yamlv3.Unmarshal(content, &root) != nil {
+		return 0, 0
+	}
+	if n := nodeForPath(&root, path); n != nil {
+		return n.Line, n.Column
+	}
+	return 0, 0
+}
+
+func (errs ValidationErrors) inContent(yamlPath string, content []byte) ValidationErrors {
+	var root yamlv3.Node
+	hasRoot := content != nil && yamlv3.Unmarshal(content, &root) == nil
+	for _, e := range errs {
+		if e.File == "" {
+			e.File = yamlPath
+		}
+		if e.Line == 0 && hasRoot {
+			if n := nodeForPath(&root, e.Path); n != nil {
+				e.Line, e.Column = n.Line, n.Column
+			}
+		}
+	}
+	return errs
+}
+
+// Sorts the errors by file, position and message.
+func (errs ValidationErrors) Sort() {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Message < b.Message
+	})
+}
+
+// Returns a human readable report of all errors, grouped by file.
+func (errs ValidationErrors) Report() string {
+	var sb strings.Builder
+	files := 0
+	lastFile := ""
+	for i, e := range errs {
+		if i == 0 || e.File != lastFile {
+			files++
+			lastFile = e.File
+		}
+		sb.WriteString(e.Error())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("%d validation error(s) found in %d file(s)\n", len(errs), files))
+	return sb.String()
+}
+
+// Returns the errors as a JSON document for consumption by other tools.
+func (errs ValidationErrors) JSON() ([]byte, error) {
+	out := struct {
+		Errors ValidationErrors `json:"errors"`
+	}{Errors: errs}
+	if out.Errors == nil {
+		out.Errors = ValidationErrors{}
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Converts an error returned by the yaml library into validation errors,
+// splitting multi-error results and resolving each line to a key path.
+func yamlValidationErrors(err error, content []byte) ValidationErrors {
+	var msgs []string
+	switch e := err.(type) {
+	case *yaml.TypeError:
+		msgs = e.Errors
+	default:
+		msgs = []string{err.Error()}
+	}
+
+	var root yamlv3.Node
+	hasRoot := yamlv3.Unmarshal(content, &root) == nil
+
+	var errs ValidationErrors
+	for _, msg := range msgs {
+		ve := &ValidationError{Message: msg}
+		if m := yamlErrorLineRegex.FindStringSubmatch(msg); m != nil {
+			ve.Line, _ = strconv.Atoi(m[1])
+			ve.Message = m[2]
+			if hasRoot {
+				ve.Path, ve.Column = pathForLine(&root, ve.Line)
+			}
+		}
+		errs = append(errs, ve)
+	}
+	return errs
+}
+
+// Splits a key path such as `properties[name].item_type` into its keys and
+// list selectors.
+func splitPath(path string) []string {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		for part != "" {
+			i := strings.Index(part, "[")
+			if i == -1 {
+				segments = append(segments, part)
+				break
+			}
+			if i > 0 {
+				segments = append(segments, part[:i])
+			}
+			j := strings.Index(part, "]")
+			if j < i {
+				segments = append(segments, part[i:])
+				break
+			}
+			segments = append(segments, part[i:j+1])
+			part = part[j+1:]
+		}
+	}
+	return segments
+}
+
+// Returns the deepest node along the given key path. For mapping keys the key
+// node itself is returned so that positions point at the key, not the value.
+func nodeForPath(root *yamlv3.Node, path string) *yamlv3.Node {
+	current := root
+	if current.Kind == yamlv3.DocumentNode && len(current.Content) > 0 {
+		current = current.Content[0]
+	}
+	var found *yamlv3.Node
+	for _, segment := range splitPath(path) {
+		if strings.HasPrefix(segment, "[") {
+			if current.Kind != yamlv3.SequenceNode {
+				return found
+			}
+			selector := strings.TrimSuffix(strings.TrimPrefix(segment, "["), "]")
+			next := sequenceElement(current, selector)
+			if next == nil {
+				return found
+			}
+			current, found = next, next
+			continue
+		}
+		if current.Kind != yamlv3.MappingNode {
+			return found
+		}
+		var next *yamlv3.Node
+		for i := 0; i+1 < len(current.Content); i += 2 {
+			if current.Content[i].Value == segment {
+				found, next = current.Content[i], current.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return found
+		}
+		current = next
+	}
+	return found
+}
+
+func sequenceElement(seq *yamlv3.Node, selector string) *yamlv3.Node {
+	for _, item := range seq.Content {
+		if item.Kind != yamlv3.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			if item.Content[i].Value == "name" && item.Content[i+1].Value == selector {
+				return item
+			}
+		}
+	}
+	if i, err := strconv.Atoi(selector); err == nil && i >= 0 && i < len(seq.Content) {
+		return seq.Content[i]
+	}
+	return nil
+}
+
+// Returns the key path and column of the deepest mapping key on a line.
+func pathForLine(root *yamlv3.Node, line int) (string, int) {
+	current := root
+	if current.Kind == yamlv3.DocumentNode && len(current.Content) > 0 {
+		current = current.Content[0]
+	}
+	path, column := "", 0
+	for current != nil {
+		var next *yamlv3.Node
+		switch current.Kind {
+		case yamlv3.MappingNode:
+			var key *yamlv3.Node
+			for i := 0; i+1 < len(current.Content) && current.Content[i].Line <= line; i += 2 {
+				key, next = current.Content[i], current.Content[i+1]
+			}
+			if key == nil {
+				return path, column
+			}
+			path, column = joinPath(path, key.Value), key.Column
+			if key.Line == line {
+				return path, column
+			}
+		case yamlv3.SequenceNode:
+			index := -1
+			for i, item := range current.Content {
+				if item.Line > line {
+					break
+				}
+				index, next = i, item
+			}
+			if index == -1 {
+				return path, column
+			}
+			path = fmt.Sprintf("%s[%s]", path, elementSelector(next, index))
+			column = next.Column
+		}
+		current = next
+	}
+	return path, column
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+func elementSelector(item *yamlv3.Node, index int) string {
+	if item.Kind == yamlv3.MappingNode {
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			if item.Content[i].Value == "name" {
+				return item.Content[i+1].Value
+			}
+		}
+	}
+	return strconv.Itoa(index)
+}
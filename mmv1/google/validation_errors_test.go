@@ -0,0 +1,125 @@
+package google
+
+import (
+	"testing"
+)
+
+const validationTestYaml = `name: 'Topic'
+description: 'A topic'
+properties:
+  - name: 'labels'
+    type: KeyValueLabels
+  - name: 'config'
+    type: NestedObject
+    properties:
+      - name: 'kmsKeyName'
+        type: String
+        bogus: true
+`
+
+func TestYamlValidatorParse(t *testing.T) {
+	t.Parallel()
+
+	type prop struct {
+		Name       string
+		Type       string
+		Properties []prop
+	}
+	obj := struct {
+		Name        string
+		Description string
+		Properties  []prop
+	}{}
+
+	v := YamlValidator{}
+	err := v.Parse([]byte(validationTestYaml), &obj, "Topic.yaml")
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("expected a single ValidationError, got %#v", err)
+	}
+
+	got := errs[0]
+	if got.File != "Topic.yaml" || got.Line != 11 || got.Column != 9 {
+		t.Errorf("expected position Topic.yaml:11:9, got %s:%d:%d", got.File, got.Line, got.Column)
+	}
+	if want := "properties[config].properties[kmsKeyName].bogus"; got.Path != want {
+		t.Errorf("expected path %q, got %q", want, got.Path)
+	}
+}
+
+func TestValidationErrorsInContent(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		path        string
+		line        int
+		column      int
+	}{
+		{
+			description: "top level key",
+			path:        "description",
+			line:        2,
+			column:      1,
+		},
+		{
+			description: "list element selected by name",
+			path:        "properties[config].type",
+			line:        7,
+			column:      5,
+		},
+		{
+			description: "nested list element",
+			path:        "properties[config].properties[kmsKeyName]",
+			line:        9,
+			column:      9,
+		},
+		{
+			description: "list element selected by index",
+			path:        "properties[0].name",
+			line:        4,
+			column:      5,
+		},
+		{
+			description: "generated field falls back to closest parent",
+			path:        "properties[effectiveLabels].type",
+			line:        3,
+			column:      1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var errs ValidationErrors
+			errs.Addf(tc.path, "problem")
+			errs.inContent("Topic.yaml", []byte(validationTestYaml))
+
+			if errs[0].Line != tc.line || errs[0].Column != tc.column {
+				t.Errorf("expected %d:%d, got %d:%d", tc.line, tc.column, errs[0].Line, errs[0].Column)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsNest(t *testing.T) {
+	t.Parallel()
+
+	var child ValidationErrors
+	child.Addf("", "self")
+	child.Addf("name", "key")
+	child.Addf("[0]", "element")
+
+	var errs ValidationErrors
+	errs.Nest("properties[foo]", child)
+
+	want := []string{"properties[foo]", "properties[foo].name", "properties[foo][0]"}
+	for i, e := range errs {
+		if e.Path != want[i] {
+			t.Errorf("expected path %q, got %q", want[i], e.Path)
+		}
+	}
+}
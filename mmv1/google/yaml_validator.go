@@ -14,16 +14,21 @@
 package google
 
 import (
-	"log"
-
 	"gopkg.in/yaml.v2"
 )
 
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+// Unmarshals content into obj, returning every problem the yaml library
+// reports as ValidationErrors attributed to yamlPath.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	if err := yaml.UnmarshalStrict(content, obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+		errs := yamlValidationErrors(err, content)
+		for _, e := range errs {
+			e.File = yamlPath
+		}
+		return errs
 	}
+	return nil
 }
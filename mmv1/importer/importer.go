@@ -0,0 +1,104 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer holds the helpers shared by the generators of resource
+// YAML from API descriptions, and by the checks of resources against them.
+package importer
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// License header and document start marker written at the top of generated
+// YAML files
+//
+//go:embed header.txt
+var Header []byte
+
+// Recursive schemas are expanded this many times before they are cut off
+const MaxRecursionDepth = 2
+
+// Counts the number of times each schema appears in the chain of fields
+// currently being built, used to break reference cycles. Schemas are
+// identified by K, for example a pointer or a reference name.
+type RecursionGuard[K comparable] struct {
+	depth map[K]int
+}
+
+func NewRecursionGuard[K comparable]() RecursionGuard[K] {
+	return RecursionGuard[K]{depth: map[K]int{}}
+}
+
+// Records that the schema is being expanded. Returns false if it is already
+// being expanded MaxRecursionDepth times, in which case Leave must not be
+// called.
+func (g RecursionGuard[K]) Enter(schema K) bool {
+	if g.depth[schema] >= MaxRecursionDepth {
+		return false
+	}
+	g.depth[schema]++
+	return true
+}
+
+func (g RecursionGuard[K]) Leave(schema K) {
+	g.depth[schema]--
+}
+
+// Returns the enum values without the *_UNSPECIFIED default.
+func EnumValues[T any](values []T) []string {
+	var enums []string
+	for _, v := range values {
+		value := fmt.Sprintf("%v", v)
+		if strings.HasSuffix(value, "_UNSPECIFIED") {
+			continue
+		}
+		enums = append(enums, value)
+	}
+	return enums
+}
+
+// Sets the type of a string field that has enum values. Array items are typed
+// Enum, other fields stay String and the values are returned as a suffix for
+// their description.
+func SetEnumType(field *api.Type, values []string, item bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if item {
+		field.Type = "Enum"
+		field.EnumValues = values
+		field.Description = "This field only has a name and description because of MM\nlimitations. It should not appear in downstreams."
+		return ""
+	}
+	return fmt.Sprintf("\n Possible values:\n %s", strings.Join(values, "\n"))
+}
+
+// Trims whitespace from the ends of lines in a description to force multiline
+// formatting for strings with newlines present
+// Also trim "Output only." and "Required." from descriptions as this gets duplicated
+func TrimDescription(description string) string {
+	description = strings.TrimSpace(description)
+	for _, prefix := range []string{"Optional. ", "Output only. ", "Required. ", "Immutable. "} {
+		description, _ = strings.CutPrefix(description, prefix)
+	}
+	lines := strings.Split(description, "\n")
+	var trimmedDescription []string
+	for _, line := range lines {
+		trimmedDescription = append(trimmedDescription, strings.Trim(line, " "))
+	}
+	return strings.Join(trimmedDescription, "\n")
+}
@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"golang.org/x/exp/slices"
+)
+
+func TestRecursionGuard(t *testing.T) {
+	t.Parallel()
+
+	g := NewRecursionGuard[string]()
+	for i := 0; i < MaxRecursionDepth; i++ {
+		if !g.Enter("Network") {
+			t.Fatalf("Enter() = false after %d levels, want true", i)
+		}
+	}
+	if g.Enter("Network") {
+		t.Errorf("Enter() = true after %d levels, want false", MaxRecursionDepth)
+	}
+	if !g.Enter("Subnetwork") {
+		t.Errorf("Enter() of another schema = false, want true")
+	}
+	g.Leave("Network")
+	if !g.Enter("Network") {
+		t.Errorf("Enter() after Leave() = false, want true")
+	}
+}
+
+func TestSetEnumType(t *testing.T) {
+	t.Parallel()
+
+	values := EnumValues([]any{"MODE_UNSPECIFIED", "ON", "OFF"})
+	if !slices.Equal(values, []string{"ON", "OFF"}) {
+		t.Fatalf("EnumValues() = %v, want [ON OFF]", values)
+	}
+
+	field := api.Type{Type: "String"}
+	if got := SetEnumType(&field, values, false); got != "\n Possible values:\n ON\nOFF" || field.Type != "String" {
+		t.Errorf("SetEnumType() of a field = %q with type %s, want the values in the description of a String", got, field.Type)
+	}
+
+	item := api.Type{Type: "String"}
+	if got := SetEnumType(&item, values, true); got != "" || item.Type != "Enum" || !slices.Equal(item.EnumValues, values) {
+		t.Errorf("SetEnumType() of an item = %q with type %s and values %v, want an Enum", got, item.Type, item.EnumValues)
+	}
+}
+
+func TestTrimDescription(t *testing.T) {
+	t.Parallel()
+
+	if got, want := TrimDescription("  Output only. The  name. \n Second line.\n"), "The  name.\nSecond line."; got != want {
+		t.Errorf("TrimDescription() = %q, want %q", got, want)
+	}
+}
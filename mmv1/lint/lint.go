@@ -0,0 +1,274 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint runs semantic checks over MMv1 resource YAML that are not
+// strictly required for generation, but usually point at a mistake.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+	SeverityOff     Severity = "off"
+)
+
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case SeverityError, SeverityWarning, SeverityNote, SeverityOff:
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown severity %q, expected one of error, warning, note, off", s)
+}
+
+// A Rule checks a single loaded resource. Check returns findings with key
+// paths relative to the resource YAML; the runner fills in the rule, the
+// severity and the file position.
+type Rule struct {
+	// Identifier used in reports, suppression comments and --severity flags
+	Name string
+
+	Description string
+
+	DefaultSeverity Severity
+
+	Check func(r *api.Resource) []Finding
+}
+
+// A problem reported by a rule
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s [%s] %s", f.File, f.Line, f.Column, f.Severity, f.Rule, f.Message)
+}
+
+var rules = map[string]*Rule{}
+
+// Adds a rule to the registry. Rules register themselves from init functions;
+// registering two rules with the same name panics.
+func Register(rule *Rule) {
+	if _, ok := rules[rule.Name]; ok {
+		panic(fmt.Sprintf("lint rule %s registered twice", rule.Name))
+	}
+	rules[rule.Name] = rule
+}
+
+// Returns all registered rules sorted by name.
+func Rules() []*Rule {
+	var all []*Rule
+	for _, r := range rules {
+		all = append(all, r)
+	}
+	slices.SortFunc(all, func(a, b *Rule) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return all
+}
+
+type Options struct {
+	// Product directories to lint, for example "products/pubsub"
+	Products []string
+
+	// Per-rule severity overrides. SeverityOff disables a rule.
+	Severities map[string]Severity
+}
+
+func (o Options) severity(rule *Rule) Severity {
+	if s, ok := o.Severities[rule.Name]; ok {
+		return s
+	}
+	return rule.DefaultSeverity
+}
+
+// Loads every resource in the given products and runs all enabled rules over
+// them. Files that fail to load are reported as findings of the `yaml` rule.
+func Run(opts Options) ([]Finding, error) {
+	for name := range opts.Severities {
+		if _, ok := rules[name]; !ok && name != yamlRuleName {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+	}
+
+	var findings []Finding
+	for _, productDir := range opts.Products {
+		productFindings, err := runProduct(productDir, opts)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, productFindings...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Rule < b.Rule
+	})
+	return findings, nil
+}
+
+const yamlRuleName = "yaml"
+
+func runProduct(productDir string, opts Options) ([]Finding, error) {
+	var findings []Finding
+	yamlSeverity := SeverityError
+	if s, ok := opts.Severities[yamlRuleName]; ok {
+		yamlSeverity = s
+	}
+	loadFailed := func(err error) {
+		if yamlSeverity == SeverityOff {
+			return
+		}
+		errs, ok := err.(google.ValidationErrors)
+		if !ok {
+			errs = google.ValidationErrors{{Message: err.Error()}}
+		}
+		for _, e := range errs {
+			findings = append(findings, Finding{
+				Rule:     yamlRuleName,
+				Severity: yamlSeverity,
+				File:     e.File,
+				Line:     e.Line,
+				Column:   e.Column,
+				Path:     e.Path,
+				Message:  e.Message,
+			})
+		}
+	}
+
+	productApi := &api.Product{}
+	if err := api.Compile(filepath.Join(productDir, "product.yaml"), productApi, ""); err != nil {
+		loadFailed(err)
+		return findings, nil
+	}
+
+	resourceFiles, err := filepath.Glob(filepath.Join(productDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceYamlPath := range resourceFiles {
+		if filepath.Base(resourceYamlPath) == "product.yaml" {
+			continue
+		}
+
+		resource := &api.Resource{}
+		if err := api.Compile(resourceYamlPath, resource, ""); err != nil {
+			loadFailed(err)
+			continue
+		}
+		resource.SourceYamlFile = resourceYamlPath
+		resource.SetDefault(productApi)
+
+		content, err := os.ReadFile(resourceYamlPath)
+		if err != nil {
+			return nil, err
+		}
+		suppressions := parseSuppressions(content)
+
+		for _, rule := range Rules() {
+			severity := opts.severity(rule)
+			if severity == SeverityOff {
+				continue
+			}
+			for _, f := range rule.Check(resource) {
+				f.Rule = rule.Name
+				f.Severity = severity
+				f.File = resourceYamlPath
+				f.Line, f.Column = google.PositionForPath(content, f.Path)
+				if suppressions.suppressed(f) {
+					continue
+				}
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// Suppression comments have the form
+//
+//	# mmv1-lint: disable=rule-a,rule-b
+//
+// at the end of the reported line or on its own line directly above it, or
+//
+//	# mmv1-lint: disable-file=rule-a
+//
+// anywhere in the file. `all` matches every rule.
+var suppressionRegex = regexp.MustCompile(`#\s*mmv1-lint:\s*(disable|disable-file)=([\w,-]+)`)
+
+type suppressions struct {
+	file  []string
+	lines map[int][]string
+}
+
+func parseSuppressions(content []byte) suppressions {
+	s := suppressions{lines: map[int][]string{}}
+	for i, line := range strings.Split(string(content), "\n") {
+		m := suppressionRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		names := strings.Split(m[2], ",")
+		if m[1] == "disable-file" {
+			s.file = append(s.file, names...)
+			continue
+		}
+		lineNum := i + 1
+		// A comment on its own line applies to the next line
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lineNum++
+		}
+		s.lines[lineNum] = append(s.lines[lineNum], names...)
+	}
+	return s
+}
+
+func (s suppressions) suppressed(f Finding) bool {
+	matches := func(names []string) bool {
+		return slices.Contains(names, f.Rule) || slices.Contains(names, "all")
+	}
+	return matches(s.file) || matches(s.lines[f.Line])
+}
+
+// Reports whether any finding has error severity.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool {
+		return f.Severity == SeverityError
+	})
+}
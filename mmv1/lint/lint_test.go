@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestSuppressions(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`name: 'Topic'
+# mmv1-lint: disable-file=unused-example-var
+identity:
+  # mmv1-lint: disable=identity-not-in-self-link
+  - name
+  - other # mmv1-lint: disable=all
+  - third
+`)
+	s := parseSuppressions(content)
+
+	cases := []struct {
+		description string
+		finding     Finding
+		expected    bool
+	}{
+		{
+			description: "file level",
+			finding:     Finding{Rule: "unused-example-var", Line: 1},
+			expected:    true,
+		},
+		{
+			description: "comment on the line above",
+			finding:     Finding{Rule: "identity-not-in-self-link", Line: 5},
+			expected:    true,
+		},
+		{
+			description: "comment on the line above only applies to the named rule",
+			finding:     Finding{Rule: "enum-unspecified", Line: 5},
+			expected:    false,
+		},
+		{
+			description: "trailing comment for all rules",
+			finding:     Finding{Rule: "enum-unspecified", Line: 6},
+			expected:    true,
+		},
+		{
+			description: "unsuppressed line",
+			finding:     Finding{Rule: "identity-not-in-self-link", Line: 7},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := s.suppressed(tc.finding); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckEnumUnspecified(t *testing.T) {
+	t.Parallel()
+
+	r := &api.Resource{
+		Properties: []*api.Type{
+			{
+				Name: "config",
+				Type: "NestedObject",
+				Properties: []*api.Type{
+					{
+						Name:       "mode",
+						Type:       "Enum",
+						EnumValues: []string{"MODE_UNSPECIFIED", "ON"},
+					},
+				},
+			},
+			{
+				Name: "modes",
+				Type: "Array",
+				ItemType: &api.Type{
+					Name:       "mode",
+					Type:       "Enum",
+					EnumValues: []string{"ON", "OFF"},
+				},
+			},
+		},
+	}
+
+	findings := checkEnumUnspecified(r)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %#v", findings)
+	}
+	if want := "properties[config].properties[mode].enum_values"; findings[0].Path != want {
+		t.Errorf("expected path %q, got %q", want, findings[0].Path)
+	}
+}
+
+func TestCheckIdentityInSelfLink(t *testing.T) {
+	t.Parallel()
+
+	r := &api.Resource{
+		SelfLink: "projects/{{project}}/topics/{{%topic_id}}",
+		Identity: []string{"topicId", "name"},
+	}
+	findings := checkIdentityInSelfLink(r)
+	if len(findings) != 1 || findings[0].Path != "identity[1]" {
+		t.Errorf("expected a single finding for identity[1], got %#v", findings)
+	}
+
+	r.NestedQuery = &resource.NestedQuery{Keys: []string{"topics"}}
+	if findings := checkIdentityInSelfLink(r); len(findings) != 0 {
+		t.Errorf("expected no findings for nested_query resources, got %#v", findings)
+	}
+}
+
+func TestCheckUpdateMaskFields(t *testing.T) {
+	t.Parallel()
+
+	r := &api.Resource{
+		UpdateMask: true,
+		UpdateVerb: "PATCH",
+		Properties: []*api.Type{
+			{Name: "description", ApiName: "description", Type: "String"},
+			{Name: "labels", ApiName: "labels", Type: "KeyValuePairs", UpdateMaskFields: []string{"labels"}},
+			{Name: "zone", ApiName: "zone", Type: "String", Immutable: true},
+			{Name: "state", ApiName: "state", Type: "String", Output: true},
+			{Name: "config", ApiName: "config", Type: "NestedObject", FlattenObject: true, Properties: []*api.Type{
+				{Name: "size", ApiName: "size", Type: "Integer"},
+			}},
+		},
+	}
+	r.SetDefault(&api.Product{Name: "Test"})
+	findings := checkUpdateMaskFields(r)
+	var paths []string
+	for _, f := range findings {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"properties[description]", "properties[config].properties[size]"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("expected findings at %v, got %v", want, paths)
+	}
+
+	r.UpdateMask = false
+	if findings := checkUpdateMaskFields(r); len(findings) != 0 {
+		t.Errorf("expected no findings without update_mask, got %#v", findings)
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"text", "json", "sarif"} {
+		if err := CheckFormat(format); err != nil {
+			t.Errorf("expected format %q to be valid, got %v", format, err)
+		}
+	}
+	if err := CheckFormat("xml"); err == nil {
+		t.Errorf("expected an error for format xml")
+	}
+}
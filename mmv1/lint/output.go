@@ -0,0 +1,169 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// Returns an error if format is not one of the formats supported by Write.
+func CheckFormat(format string) error {
+	switch format {
+	case "text", "json", "sarif":
+		return nil
+	}
+	return fmt.Errorf("unknown lint output format %q, expected one of text, json, sarif", format)
+}
+
+// Writes findings in the given format: text, json or sarif. In SARIF output,
+// file URIs are prefixed with uriPrefix so they can be made relative to the
+// repository root, for example "mmv1/".
+func Write(w io.Writer, format string, findings []Finding, uriPrefix string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		return writeJSON(w, findings)
+	case "sarif":
+		return writeSarif(w, findings, uriPrefix)
+	}
+	return writeText(w, findings)
+}
+
+func writeText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintln(w, f.String()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d finding(s)\n", len(findings))
+	return err
+}
+
+func writeJSON(w io.Writer, findings []Finding) error {
+	out := struct {
+		Findings []Finding `json:"findings"`
+	}{Findings: findings}
+	if out.Findings == nil {
+		out.Findings = []Finding{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// Minimal subset of the SARIF 2.1.0 format understood by code scanning tools
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSarif(w io.Writer, findings []Finding, uriPrefix string) error {
+	driver := sarifDriver{Name: "mmv1-lint"}
+	driver.Rules = append(driver.Rules, sarifRule{
+		Id:                   yamlRuleName,
+		ShortDescription:     sarifMessage{Text: "The file must load and validate as MMv1 YAML."},
+		DefaultConfiguration: sarifConfiguration{Level: string(SeverityError)},
+	})
+	for _, r := range Rules() {
+		driver.Rules = append(driver.Rules, sarifRule{
+			Id:                   r.Name,
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfiguration{Level: string(r.DefaultSeverity)},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, f := range findings {
+		loc := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{Uri: filepath.ToSlash(uriPrefix + f.File)},
+		}
+		if f.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+		}
+		results = append(results, sarifResult{
+			RuleId:    f.Rule,
+			Level:     string(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+func init() {
+	Register(&Rule{
+		Name:            "update-mask-fields",
+		Description:     "Updatable properties of `update_mask` resources should map to their mask with `update_mask_fields` instead of relying on the `api_name` default.",
+		DefaultSeverity: SeverityNote,
+		Check:           checkUpdateMaskFields,
+	})
+	Register(&Rule{
+		Name:            "immutable-update-url",
+		Description:     "`immutable` resources are never updated as a whole, so a resource-level `update_url` is unused.",
+		DefaultSeverity: SeverityWarning,
+		Check:           checkImmutableUpdateUrl,
+	})
+	Register(&Rule{
+		Name:            "identity-not-in-self-link",
+		Description:     "Every `identity` entry of a resource without `nested_query` should appear as a parameter of `self_link`.",
+		DefaultSeverity: SeverityWarning,
+		Check:           checkIdentityInSelfLink,
+	})
+	Register(&Rule{
+		Name:            "enum-unspecified",
+		Description:     "`enum_values` should not contain the `*_UNSPECIFIED` default value of the API enum.",
+		DefaultSeverity: SeverityWarning,
+		Check:           checkEnumUnspecified,
+	})
+	Register(&Rule{
+		Name:            "unused-example-var",
+		Description:     "Example `vars` should be used in the example template.",
+		DefaultSeverity: SeverityWarning,
+		Check:           checkUnusedExampleVars,
+	})
+}
+
+// Calls fn for every property and parameter in the resource, including nested
+// ones, together with its key path in the resource YAML.
+func walkProperties(r *api.Resource, fn func(t *api.Type, path string)) {
+	for _, p := range r.Properties {
+		walkProperty(p, fmt.Sprintf("properties[%s]", p.Name), fn)
+	}
+	for _, p := range r.Parameters {
+		walkProperty(p, fmt.Sprintf("parameters[%s]", p.Name), fn)
+	}
+}
+
+func walkProperty(t *api.Type, path string, fn func(t *api.Type, path string)) {
+	fn(t, path)
+	for _, p := range t.Properties {
+		walkProperty(p, fmt.Sprintf("%s.properties[%s]", path, p.Name), fn)
+	}
+	if t.ItemType != nil {
+		walkProperty(t.ItemType, path+".item_type", fn)
+	}
+	if t.ValueType != nil {
+		walkProperty(t.ValueType, path+".value_type", fn)
+	}
+}
+
+func checkUpdateMaskFields(r *api.Resource) []Finding {
+	if !r.UpdateMask || r.Immutable {
+		return nil
+	}
+	var findings []Finding
+	var check func(props []*api.Type, path string)
+	check = func(props []*api.Type, path string) {
+		for _, p := range props {
+			pPath := fmt.Sprintf("%sproperties[%s]", path, p.Name)
+			if p.FlattenObject {
+				check(p.Properties, pPath+".")
+				continue
+			}
+			if len(p.UpdateMaskFields) > 0 || p.IsForceNew() {
+				continue
+			}
+			findings = append(findings, Finding{
+				Path:    pPath,
+				Message: fmt.Sprintf("property %s has no update_mask_fields; the update mask defaults to %q", p.Name, p.ApiName),
+			})
+		}
+	}
+	check(r.UpdateBodyProperties(), "")
+	return findings
+}
+
+func checkImmutableUpdateUrl(r *api.Resource) []Finding {
+	if !r.Immutable || r.UpdateUrl == "" {
+		return nil
+	}
+	return []Finding{{
+		Path:    "update_url",
+		Message: fmt.Sprintf("resource %s is immutable but sets update_url %q", r.Name, r.UpdateUrl),
+	}}
+}
+
+var urlParamRegex = regexp.MustCompile(`{{%?(\w+)}}`)
+
+func checkIdentityInSelfLink(r *api.Resource) []Finding {
+	// Nested resources are matched by their identity within the parent's list
+	if r.NestedQuery != nil {
+		return nil
+	}
+
+	var params []string
+	for _, m := range urlParamRegex.FindAllStringSubmatch(r.SelfLinkUri(), -1) {
+		params = append(params, m[1])
+	}
+
+	var findings []Finding
+	for i, id := range r.Identity {
+		if slices.Contains(params, google.Underscore(id)) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Path:    fmt.Sprintf("identity[%d]", i),
+			Message: fmt.Sprintf("identity %s does not appear in self_link %q", id, r.SelfLinkUri()),
+		})
+	}
+	return findings
+}
+
+func checkEnumUnspecified(r *api.Resource) []Finding {
+	var findings []Finding
+	walkProperties(r, func(t *api.Type, path string) {
+		for _, v := range t.EnumValues {
+			if strings.HasSuffix(v, "_UNSPECIFIED") {
+				findings = append(findings, Finding{
+					Path:    path + ".enum_values",
+					Message: fmt.Sprintf("enum_values of %s contains %s", t.Name, v),
+				})
+			}
+		}
+	})
+	return findings
+}
+
+var exampleVarRegex = regexp.MustCompile(`index \$\.Vars "(\w+)"|\.Vars\.(\w+)`)
+
+func checkUnusedExampleVars(r *api.Resource) []Finding {
+	var findings []Finding
+	for _, e := range r.Examples {
+		if len(e.Vars) == 0 {
+			continue
+		}
+		content, err := os.ReadFile(e.ConfigPath)
+		if err != nil {
+			findings = append(findings, Finding{
+				Path:    fmt.Sprintf("examples[%s]", e.Name),
+				Message: fmt.Sprintf("cannot read example template %s: %v", e.ConfigPath, err),
+			})
+			continue
+		}
+		used := map[string]bool{}
+		for _, m := range exampleVarRegex.FindAllStringSubmatch(string(content), -1) {
+			used[m[1]+m[2]] = true
+		}
+
+		var names []string
+		for name := range e.Vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if used[name] {
+				continue
+			}
+			findings = append(findings, Finding{
+				Path:    fmt.Sprintf("examples[%s].vars.%s", e.Name, name),
+				Message: fmt.Sprintf("var %s of example %s is not used in %s", name, e.Name, e.ConfigPath),
+			})
+		}
+	}
+	return findings
+}
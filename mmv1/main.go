@@ -1,28 +1,38 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"golang.org/x/exp/slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/conformance"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/discovery_generate"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/lint"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/versiondiff"
 )
 
-var wg sync.WaitGroup
-
 // TODO rewrite: additional flags
 
 // Example usage: --output $GOPATH/src/github.com/terraform-providers/terraform-provider-google-beta
@@ -41,19 +51,62 @@ var doNotGenerateCode = flag.Bool("no-code", false, "do not generate code")
 
 var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
-var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
+var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used. Use \"list\" to print the available providers.")
 
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
+// Example usage: --discovery-generate discovery/pubsub_v1.json
+var discoveryGenerate = flag.String("discovery-generate", "", "Generate MMv1 YAML in the products directory from a Discovery document (Experimental)")
+
+var openapiMerge = flag.Bool("openapi-merge", false, "with --openapi-generate, merge into existing resource YAML instead of overwriting it. New fields are added, removed fields are marked, descriptions and output and immutable flags are updated unless marked with a mmv1-openapi: keep comment, and all other keys are kept. Changes are printed to stdout.")
+
 var showImportDiffsFlag = flag.Bool("show-import-diffs", false, "write go import diffs to stdout")
 
+// Example usage: --cache-dir ~/.cache/mmv1
+var cacheDirFlag = flag.String("cache-dir", "", "optional directory for the incremental generation cache. If specified, resources whose inputs are unchanged since the last run with the same options are not generated again. Only supported by the default provider.")
+
+var dryRunFlag = flag.Bool("dry-run", false, "generate into a temporary copy of the output directory and report the files that would be added, modified or deleted in the output directory, with unified diffs on stdout. Nothing is written to the output directory.")
+
+// Example usage: --jobs 4
+var jobsFlag = flag.Int("jobs", runtime.NumCPU(), "maximum number of products to load and generate concurrently")
+
+// Example usage: --validation-output json
+var validationOutputFlag = flag.String("validation-output", "text", "format of the YAML validation report, one of text or json. json is written to stdout")
+
 func main() {
 
 	// Handle all flags in main. Other functions must not access flag values directly.
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "version-diff" {
+		os.Exit(runVersionDiff(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "conformance" {
+		os.Exit(runConformance(os.Args[2:]))
+	}
+
+	// Providers can add their own flags
+	provider.RegisterFlags(flag.CommandLine)
 	flag.Parse()
 
+	if *providerFlag == "list" {
+		printProviders(os.Stdout)
+		return
+	}
+	if _, err := provider.Lookup(*providerFlag); err != nil {
+		log.Fatal(err)
+	}
+
 	if *openapiGenerate {
 		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
+		parser.Merge = *openapiMerge
+		parser.Run()
+		return
+	}
+
+	if *discoveryGenerate != "" {
+		parser := discovery_generate.NewDiscoveryParser(*discoveryGenerate, "products")
 		parser.Run()
 		return
 	}
@@ -63,10 +116,243 @@ func main() {
 		return
 	}
 
-	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *showImportDiffsFlag)
+	if *validationOutputFlag != "text" && *validationOutputFlag != "json" {
+		log.Fatalf("Unknown --validation-output %q, expected text or json", *validationOutputFlag)
+	}
+
+	if *jobsFlag < 1 {
+		log.Fatalf("--jobs must be at least 1, got %d", *jobsFlag)
+	}
+
+	// Stop starting new products on interrupt. Products already being
+	// generated run to completion so that no file is left half written.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *dryRunFlag {
+		if *cacheDirFlag != "" {
+			log.Printf("Ignoring --cache-dir in dry-run mode")
+		}
+		code := dryRun(*outputPathFlag, *validationOutputFlag, *productFlag != "" || *resourceFlag != "", func(scratchOutputPath string) error {
+			return GenerateProducts(ctx, *productFlag, *resourceFlag, *providerFlag, *versionFlag, scratchOutputPath, *overrideDirectoryFlag, "", *jobsFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *showImportDiffsFlag)
+		})
+		os.Exit(code)
+	}
+
+	err := GenerateProducts(ctx, *productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *overrideDirectoryFlag, *cacheDirFlag, *jobsFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *showImportDiffsFlag)
+	if err != nil {
+		reportGenerationError(err, *validationOutputFlag)
+		os.Exit(1)
+	}
+}
+
+// Runs generate against a scratch copy of the output directory, then reports
+// how the real output directory would change. partial limits deleted files to
+// product directories. Returns the process exit code.
+func dryRun(outputPath, validationOutput string, partial bool, generate func(scratchOutputPath string) error) int {
+	scratch, err := os.MkdirTemp("", "mmv1-dry-run-")
+	if err != nil {
+		log.Fatalf("Cannot create scratch directory: %v", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	// Some generated content depends on the name of the output directory
+	scratchOutputPath := filepath.Join(scratch, filepath.Base(filepath.Clean(outputPath)))
+	// Generating over a copy runs goimports in the module of the output
+	// directory, as a real run would
+	if err := provider.CopyOutputTree(outputPath, scratchOutputPath); err != nil {
+		log.Fatalf("Cannot copy %s to scratch directory: %v", outputPath, err)
+	}
+	log.Printf("Dry run: generating into %s", scratchOutputPath)
+
+	if err := generate(scratchOutputPath); err != nil {
+		reportGenerationError(err, validationOutput)
+		return 1
+	}
+
+	var onlyDirs map[string]bool
+	if partial {
+		onlyDirs = provider.ProductOutputDirs(scratchOutputPath)
+	}
+	changes, err := provider.DiffOutput(scratchOutputPath, outputPath, onlyDirs)
+	if err != nil {
+		log.Fatalf("Cannot compare generated files with %s: %v", outputPath, err)
+	}
+	counts := map[provider.ChangeType]int{}
+	for _, c := range changes {
+		counts[c.Type]++
+		log.Printf("%s %s", c.Type, c.Path)
+	}
+	log.Printf("Dry run: %d added, %d modified, %d deleted in %s", counts[provider.FileAdded], counts[provider.FileModified], counts[provider.FileDeleted], outputPath)
+
+	if err := provider.WriteOutputDiff(os.Stdout, changes, scratchOutputPath, outputPath); err != nil {
+		log.Fatal(err)
+	}
+	return 0
+}
+
+// Runs `mmv1 lint`, returning the process exit code.
+//
+// Example usage: lint --product pubsub --format sarif --severity enum-unspecified=error
+func runLint(args []string) int {
+	lintFlags := flag.NewFlagSet("lint", flag.ExitOnError)
+	product := lintFlags.String("product", "", "optional product name. If specified, only resources under the specific product will be linted.")
+	format := lintFlags.String("format", "text", "output format, one of text, json or sarif")
+	uriPrefix := lintFlags.String("sarif-uri-prefix", "mmv1/", "prefix added to file paths in SARIF output")
+	listRules := lintFlags.Bool("list-rules", false, "list the available rules and exit")
+	severities := map[string]lint.Severity{}
+	lintFlags.Func("severity", "override the severity of a rule, as rule=error|warning|note|off. May be repeated.", func(v string) error {
+		name, level, ok := strings.Cut(v, "=")
+		if !ok {
+			return fmt.Errorf("expected rule=severity, got %q", v)
+		}
+		severity, err := lint.ParseSeverity(level)
+		if err != nil {
+			return err
+		}
+		severities[name] = severity
+		return nil
+	})
+	lintFlags.Parse(args)
+
+	if err := lint.CheckFormat(*format); err != nil {
+		log.Fatal(err)
+	}
+
+	if *listRules {
+		for _, r := range lint.Rules() {
+			fmt.Printf("%-28s %-8s %s\n", r.Name, r.DefaultSeverity, r.Description)
+		}
+		return 0
+	}
+
+	var products []string
+	if *product != "" {
+		products = []string{fmt.Sprintf("products/%s", *product)}
+	} else {
+		files, err := filepath.Glob("products/**/product.yaml")
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, filePath := range files {
+			products = append(products, filepath.Dir(filePath))
+		}
+	}
+
+	findings, err := lint.Run(lint.Options{Products: products, Severities: severities})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := lint.Write(os.Stdout, *format, findings, *uriPrefix); err != nil {
+		log.Fatal(err)
+	}
+	if lint.HasErrors(findings) {
+		return 1
+	}
+	return 0
+}
+
+// Runs `mmv1 version-diff`, returning the process exit code.
+//
+// Example usage: version-diff --product pubsub --format json
+func runVersionDiff(args []string) int {
+	diffFlags := flag.NewFlagSet("version-diff", flag.ExitOnError)
+	product := diffFlags.String("product", "", "optional product name. If specified, only resources under the specific product will be compared.")
+	format := diffFlags.String("format", "text", "output format, one of text or json")
+	diffFlags.Parse(args)
+
+	var products []string
+	if *product != "" {
+		products = []string{fmt.Sprintf("products/%s", *product)}
+	} else {
+		files, err := filepath.Glob("products/**/product.yaml")
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, filePath := range files {
+			products = append(products, filepath.Dir(filePath))
+		}
+	}
+
+	diffs, err := versiondiff.Run(versiondiff.Options{Products: products})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := versiondiff.Write(os.Stdout, *format, diffs); err != nil {
+		log.Fatal(err)
+	}
+	return 0
 }
 
-func GenerateProducts(product, resource, providerName, version, outputPath, overrideDirectory string, generateCode, generateDocs, showImportDiffs bool) {
+// Runs `mmv1 conformance`, returning the process exit code: 1 if the
+// resources differ from the API.
+//
+// Example usage: conformance --product pubsub --spec discovery/pubsub_v1.json
+func runConformance(args []string) int {
+	conformanceFlags := flag.NewFlagSet("conformance", flag.ExitOnError)
+	product := conformanceFlags.String("product", "", "name of the product whose resources are checked")
+	spec := conformanceFlags.String("spec", "", "path to the OpenAPI or Discovery document of the API of the product")
+	version := conformanceFlags.String("version", "ga", "provider version whose fields are checked")
+	format := conformanceFlags.String("format", "text", "output format, one of text or json")
+	conformanceFlags.Parse(args)
+
+	if *product == "" || *spec == "" {
+		log.Fatal("--product and --spec are required")
+	}
+
+	findings, err := conformance.Run(conformance.Options{
+		Product: fmt.Sprintf("products/%s", *product),
+		Spec:    *spec,
+		Version: *version,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := conformance.Write(os.Stdout, *format, findings); err != nil {
+		log.Fatal(err)
+	}
+	if len(findings) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Writes the combined validation report for every product and resource that
+// failed to load, in the requested format. Other errors returned by
+// GenerateProducts are logged as text.
+func reportGenerationError(err error, format string) {
+	var errs google.ValidationErrors
+	if !errors.As(err, &errs) {
+		log.Printf("Generation failed:\n%v", err)
+		return
+	}
+	if format == "json" {
+		out, err := errs.JSON()
+		if err != nil {
+			log.Fatalf("Cannot marshal validation errors: %v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+	log.Printf("Validation failed:\n%s", errs.Report())
+}
+
+// The outcome of loading and generating a single product.
+type productResult struct {
+	productName string
+	// Set when the product exists at the requested version and is valid
+	product          *api.Product
+	generated        bool
+	validationErrors google.ValidationErrors
+	err              error
+}
+
+// Generates the requested products, loading at most jobs products at a time.
+// Failures do not stop the run; they are collected across all products and
+// returned, in which case nothing shared across products is generated. YAML
+// loading and validation problems are returned as google.ValidationErrors.
+// Once ctx is cancelled no further products are started.
+func GenerateProducts(ctx context.Context, product, resource, providerName, version, outputPath, overrideDirectory, cacheDir string, jobs int, generateCode, generateDocs, showImportDiffs bool) error {
 	if version == "" {
 		log.Printf("No version specified, assuming ga")
 		version = "ga"
@@ -84,7 +370,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 
 	files, err := filepath.Glob("products/**/product.yaml")
 	if err != nil {
-		panic(err)
+		return err
 	}
 	for _, filePath := range files {
 		dir := filepath.Dir(filePath)
@@ -107,7 +393,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 
 		overrideFiles, err := filepath.Glob(fmt.Sprintf("%s/products/**/product.yaml", overrideDirectory))
 		if err != nil {
-			panic(err)
+			return err
 		}
 		for _, filePath := range overrideFiles {
 			product, err := filepath.Rel(overrideDirectory, filePath)
@@ -127,30 +413,82 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 	}
 
 	if productsToGenerate == nil || len(productsToGenerate) == 0 {
-		log.Fatalf("No product.yaml file found.")
+		return errors.New("no product.yaml file found")
+	}
+
+	registration, err := provider.Lookup(providerName)
+	if err != nil {
+		return err
 	}
 
 	startTime := time.Now()
-	if providerName == "" {
-		providerName = "default (terraform)"
+	if cacheDir != "" {
+		if registration.Capabilities.GenerationCache {
+			if err := provider.EnableGenerationCache(cacheDir, overrideDirectory, registration.Name, version, outputPath, generateCode, generateDocs); err != nil {
+				return fmt.Errorf("cannot load generation cache: %w", err)
+			}
+		} else {
+			log.Printf("Generation cache is not supported by the %s provider, ignoring --cache-dir", registration.Name)
+		}
+	}
+	if !generateDocs && !registration.Capabilities.Docs {
+		log.Printf("The %s provider does not generate docs, ignoring --no-docs", registration.Name)
 	}
 	log.Printf("Generating MM output to '%s'", outputPath)
 	log.Printf("Building %s version", version)
-	log.Printf("Building %s provider", providerName)
+	log.Printf("Building %s provider", registration.Name)
 
-	productsForVersionChannel := make(chan *api.Product, len(allProductFiles))
-	for _, productFile := range allProductFiles {
+	log.Printf("Using %d jobs", jobs)
+
+	// Results are stored by index so that the outcome does not depend on the
+	// order in which products finish.
+	slices.Sort(allProductFiles)
+	results := make([]productResult, len(allProductFiles))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
 		wg.Add(1)
-		go GenerateProduct(version, providerName, productFile, outputPath, productsForVersionChannel, startTime, productsToGenerate, resource, overrideDirectory, generateCode, generateDocs)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = GenerateProduct(ctx, version, providerName, allProductFiles[i], outputPath, startTime, productsToGenerate, resource, overrideDirectory, generateCode, generateDocs)
+			}
+		}()
 	}
+	for i := range allProductFiles {
+		indexes <- i
+	}
+	close(indexes)
 	wg.Wait()
 
-	close(productsForVersionChannel)
-
+	var validationErrors google.ValidationErrors
+	var productErrors []error
 	var productsForVersion []*api.Product
-	for p := range productsForVersionChannel {
-		productsForVersion = append(productsForVersion, p)
+	for _, r := range results {
+		validationErrors = append(validationErrors, r.validationErrors...)
+		// Products that were not started are covered by the error of ctx
+		if r.err != nil && !errors.Is(r.err, ctx.Err()) {
+			productErrors = append(productErrors, fmt.Errorf("%s: %w", r.productName, r.err))
+		}
+		if r.product != nil {
+			productsForVersion = append(productsForVersion, r.product)
+		}
+	}
+	logGenerationSummary(results)
+	if len(validationErrors) > 0 {
+		validationErrors.Sort()
+		return validationErrors
 	}
+	if err := ctx.Err(); err != nil {
+		productErrors = append(productErrors, fmt.Errorf("generation interrupted: %w", err))
+	}
+	if len(productErrors) > 0 {
+		return errors.Join(productErrors...)
+	}
+	if len(productsForVersion) == 0 {
+		return fmt.Errorf("no product exists at version %s", version)
+	}
+
 	slices.SortFunc(productsForVersion, func(p1, p2 *api.Product) int {
 		return strings.Compare(strings.ToLower(p1.Name), strings.ToLower(p2.Name))
 	})
@@ -165,10 +503,75 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 	}
 
 	provider.FixImports(outputPath, showImportDiffs)
+
+	if err := provider.SaveGenerationCache(); err != nil {
+		log.Printf("Cannot save generation cache: %v", err)
+	}
+
+	return nil
+}
+
+// Logs how many products were generated, skipped, cancelled and failed,
+// followed by the failed products in name order.
+func logGenerationSummary(results []productResult) {
+	var generated, skipped, cancelled int
+	var failed []string
+	for _, r := range results {
+		switch {
+		case errors.Is(r.err, context.Canceled):
+			cancelled++
+		case r.err != nil || len(r.validationErrors) > 0:
+			failed = append(failed, r.productName)
+		case r.generated:
+			generated++
+		default:
+			skipped++
+		}
+	}
+	log.Printf("Products: %d generated, %d skipped, %d cancelled, %d failed", generated, skipped, cancelled, len(failed))
+	for _, name := range failed {
+		log.Printf("Failed: %s", name)
+	}
 }
 
-func GenerateProduct(version, providerName, productName, outputPath string, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
-	defer wg.Done()
+// Loads, validates and generates a single product. The product is not
+// generated when it has validation errors, or when ctx is cancelled before
+// generation starts. Errors and panics during generation are returned as
+// errors. Messages are written to the standard logger at once when the
+// product is done, so that they are not interleaved with other products.
+func GenerateProduct(ctx context.Context, version, providerName, productName, outputPath string, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) (result productResult) {
+	result.productName = productName
+	if err := ctx.Err(); err != nil {
+		result.err = err
+		return result
+	}
+
+	var logs bytes.Buffer
+	logger := log.New(&logs, "", log.Flags())
+	defer flushProductLogs(&logs)
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.product = nil
+			result.err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	var validationErrors google.ValidationErrors
+	defer func() {
+		result.validationErrors = validationErrors
+		if len(validationErrors) > 0 {
+			result.product = nil
+		}
+	}()
+	// Records the errors returned by api.Compile and reports whether loading succeeded
+	compile := func(yamlPath string, obj interface{}) bool {
+		err := api.Compile(yamlPath, obj, overrideDirectory)
+		if errs, ok := err.(google.ValidationErrors); ok {
+			validationErrors = append(validationErrors, errs...)
+		}
+		return err == nil
+	}
 
 	productYamlPath := path.Join(productName, "product.yaml")
 
@@ -184,35 +587,40 @@ func GenerateProduct(version, providerName, productName, outputPath string, prod
 	overrideProductExists := !errors.Is(overrideProductErr, os.ErrNotExist)
 
 	if !(baseProductExists || overrideProductExists) {
-		log.Fatalf("%s does not contain a product.yaml file", productName)
+		result.err = errors.New("does not contain a product.yaml file")
+		return result
 	}
 
 	productApi := &api.Product{}
+	productSourcePath := productYamlPath
 
 	if overrideProductExists {
+		productSourcePath = productOverridePath
 		if baseProductExists {
-			api.Compile(productYamlPath, productApi, overrideDirectory)
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct, overrideDirectory)
+			if !compile(productYamlPath, productApi) || !compile(productOverridePath, overrideApiProduct) {
+				return result
+			}
 
 			api.Merge(reflect.ValueOf(productApi), reflect.ValueOf(*overrideApiProduct))
-		} else {
-			api.Compile(productOverridePath, productApi, overrideDirectory)
+		} else if !compile(productOverridePath, productApi) {
+			return result
 		}
-	} else {
-		api.Compile(productYamlPath, productApi, overrideDirectory)
+	} else if !compile(productYamlPath, productApi) {
+		return result
 	}
 
 	var resources []*api.Resource = make([]*api.Resource, 0)
 
 	if !productApi.ExistsAtVersionOrLower(version) {
-		log.Printf("%s does not have a '%s' version, skipping", productName, version)
-		return
+		logger.Printf("%s does not have a '%s' version, skipping", productName, version)
+		return result
 	}
 
 	resourceFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productName))
 	if err != nil {
-		log.Fatalf("Cannot get resources files: %v", err)
+		result.err = fmt.Errorf("cannot get resources files: %w", err)
+		return result
 	}
 	// Base resource loop
 	for _, resourceYamlPath := range resourceFiles {
@@ -231,13 +639,15 @@ func GenerateProduct(version, providerName, productName, outputPath string, prod
 		}
 
 		resource := &api.Resource{}
-		api.Compile(resourceYamlPath, resource, overrideDirectory)
+		if !compile(resourceYamlPath, resource) {
+			continue
+		}
 		resource.SourceYamlFile = resourceYamlPath
 
 		resource.TargetVersionName = version
 		resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil, "")
 		resource.SetDefault(productApi)
-		resource.Validate()
+		validationErrors = append(validationErrors, resource.Validate().InFile(resourceYamlPath)...)
 		resources = append(resources, resource)
 	}
 
@@ -246,7 +656,8 @@ func GenerateProduct(version, providerName, productName, outputPath string, prod
 		productOverrideDir := filepath.Dir(productOverridePath)
 		overrideFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productOverrideDir))
 		if err != nil {
-			log.Fatalf("Cannot get override files: %v", err)
+			result.err = fmt.Errorf("cannot get override files: %w", err)
+			return result
 		}
 		for _, overrideYamlPath := range overrideFiles {
 			if filepath.Base(overrideYamlPath) == "product.yaml" || filepath.Ext(overrideYamlPath) != ".yaml" {
@@ -259,18 +670,20 @@ func GenerateProduct(version, providerName, productName, outputPath string, prod
 			_, baseResourceErr := os.Stat(baseResourcePath)
 			baseResourceExists := !errors.Is(baseResourceErr, os.ErrNotExist)
 			if baseResourceExists {
-				api.Compile(baseResourcePath, resource, overrideDirectory)
 				overrideResource := &api.Resource{}
-				api.Compile(overrideYamlPath, overrideResource, overrideDirectory)
+				if !compile(baseResourcePath, resource) || !compile(overrideYamlPath, overrideResource) {
+					continue
+				}
 				api.Merge(reflect.ValueOf(resource), reflect.ValueOf(*overrideResource))
-			} else {
-				api.Compile(overrideYamlPath, resource, overrideDirectory)
+			} else if !compile(overrideYamlPath, resource) {
+				continue
 			}
+			resource.OverrideYamlFile = overrideYamlPath
 
 			resource.TargetVersionName = version
 			resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil, "")
 			resource.SetDefault(productApi)
-			resource.Validate()
+			validationErrors = append(validationErrors, resource.Validate().InFile(overrideYamlPath)...)
 			resources = append(resources, resource)
 		}
 
@@ -282,32 +695,68 @@ func GenerateProduct(version, providerName, productName, outputPath string, prod
 	}
 
 	productApi.Objects = resources
-	productApi.Validate()
+	validationErrors = append(validationErrors, productApi.Validate().InFile(productSourcePath)...)
+	if len(validationErrors) > 0 {
+		return result
+	}
 
 	providerToGenerate := newProvider(providerName, version, productApi, startTime)
-	productsForVersionChannel <- productApi
+	result.product = productApi
 
 	if !slices.Contains(productsToGenerate, productName) {
-		log.Printf("%s not specified, skipping generation", productName)
-		return
+		logger.Printf("%s not specified, skipping generation", productName)
+		return result
+	}
+	if err := ctx.Err(); err != nil {
+		result.err = err
+		return result
 	}
 
-	log.Printf("%s: Generating files", productName)
+	logger.Printf("%s: Generating files", productName)
 
-	providerToGenerate.Generate(outputPath, productName, resourceToGenerate, generateCode, generateDocs)
+	if err := providerToGenerate.Generate(outputPath, productName, resourceToGenerate, generateCode, generateDocs, logger); err != nil {
+		result.product = nil
+		result.err = err
+		return result
+	}
+	result.generated = true
+	return result
 }
 
+// Serializes writes of buffered product logs to the standard logger.
+var productLogsMu sync.Mutex
+
+func flushProductLogs(logs *bytes.Buffer) {
+	if logs.Len() == 0 {
+		return
+	}
+	productLogsMu.Lock()
+	defer productLogsMu.Unlock()
+	log.Writer().Write(logs.Bytes())
+}
+
+// Creates the registered provider named providerName. Providers outside of
+// this repository are registered by blank importing their package from a
+// file in package main.
 func newProvider(providerName, version string, productApi *api.Product, startTime time.Time) provider.Provider {
-	switch providerName {
-	case "tgc":
-		return provider.NewTerraformGoogleConversion(productApi, version, startTime)
-	case "tgc_cai2hcl":
-		return provider.NewCaiToTerraformConversion(productApi, version, startTime)
-	case "tgc_next":
-		return provider.NewTerraformGoogleConversionNext(productApi, version, startTime)
-	case "oics":
-		return provider.NewTerraformOiCS(productApi, version, startTime)
-	default:
-		return provider.NewTerraform(productApi, version, startTime)
+	registration, err := provider.Lookup(providerName)
+	if err != nil {
+		// GenerateProducts looks the provider up before generating anything
+		panic(err)
+	}
+	return registration.New(productApi, version, startTime)
+}
+
+// Prints the registered providers and their capabilities.
+func printProviders(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCAPABILITIES\tDESCRIPTION")
+	for _, r := range provider.Registrations() {
+		name := r.Name
+		if name == provider.DefaultProviderName {
+			name += " (default)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, r.Capabilities, r.Description)
 	}
+	tw.Flush()
 }
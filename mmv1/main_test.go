@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+)
+
+// A provider that fails to generate the product named Bad, records the
+// products it generated and creates no files.
+type failingProvider struct {
+	product *api.Product
+}
+
+var failingProviderGenerated sync.Map
+
+func init() {
+	provider.Register(provider.Registration{
+		Name:        "test_failing",
+		Description: "fails to generate the product Bad",
+		New: func(product *api.Product, versionName string, startTime time.Time) provider.Provider {
+			return failingProvider{product: product}
+		},
+	})
+}
+
+func (p failingProvider) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	logger.Printf("generating %s", p.product.Name)
+	if p.product.Name == "Bad" {
+		return errors.New("cannot generate Bad")
+	}
+	failingProviderGenerated.Store(p.product.Name, true)
+	return nil
+}
+
+func (p failingProvider) CopyCommonFiles(outputFolder string, generateCode, generateDocs bool) {
+}
+
+func (p failingProvider) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
+}
+
+// Not parallel: generation reads the products relative to the working
+// directory, and the summary is written to the standard logger.
+func TestGenerateProductsCollectsProductErrors(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"Alpha", "Bad", "Gamma"} {
+		productDir := filepath.Join(dir, "products", strings.ToLower(name))
+		if err := os.MkdirAll(productDir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		yaml := fmt.Sprintf("name: '%s'\nversions:\n  - name: 'ga'\n    base_url: 'https://example.googleapis.com/v1/'\nscopes:\n  - 'https://www.googleapis.com/auth/cloud-platform'\n", name)
+		if err := os.WriteFile(filepath.Join(productDir, "product.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	for _, jobs := range []int{1, 3} {
+		t.Run(fmt.Sprintf("%d jobs", jobs), func(t *testing.T) {
+			logs.Reset()
+			failingProviderGenerated.Clear()
+
+			err := GenerateProducts(context.Background(), "", "", "test_failing", "ga", t.TempDir(), "", "", jobs, true, false, false)
+			if err == nil || !strings.Contains(err.Error(), "products/bad: cannot generate Bad") {
+				t.Errorf("GenerateProducts() = %v, want the error of products/bad", err)
+			}
+
+			for _, name := range []string{"Alpha", "Gamma"} {
+				if _, ok := failingProviderGenerated.Load(name); !ok {
+					t.Errorf("product %s was not generated", name)
+				}
+			}
+			for _, want := range []string{
+				"generating Alpha",
+				"generating Bad",
+				"generating Gamma",
+				"Products: 2 generated, 0 skipped, 0 cancelled, 1 failed",
+				"Failed: products/bad",
+			} {
+				if !strings.Contains(logs.String(), want) {
+					t.Errorf("log is missing %q:\n%s", want, logs.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"gopkg.in/yaml.v3"
+)
+
+// Comment added above fields of an existing resource that are no longer in
+// the OpenAPI spec. The field itself is left in place for a human to review.
+const removedFieldComment = "mmv1-openapi: not present in the OpenAPI spec"
+
+// Comment that marks the output and immutable flags of a field as set by
+// hand, so that merging keeps them whatever the spec says. It applies to one
+// flag as a comment on its line, or to all flags of the field as a comment
+// above the field.
+const keepFlagsComment = "mmv1-openapi: keep"
+
+// Matches the document start marker that ends the license header
+var documentStartRegex = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+
+// Merges a generated resource into the content of an existing resource YAML
+// file. Fields new in the spec are added, fields missing from the spec are
+// marked with a comment, and descriptions and output and immutable flags are
+// updated to match the spec, unless the flags are marked with keepFlagsComment.
+// Every other key, and every comment, is kept.
+//
+// Returns the merged content and a changelog with one entry per change. The
+// content is nil when nothing changed.
+func mergeResource(content []byte, generated api.Resource) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("expected a mapping at the top level")
+	}
+	root := doc.Content[0]
+
+	m := merger{}
+	// Parameters are derived from URLs and often added by hand, so only new
+	// ones are reported
+	m.mergeFields(root, "parameters", generated.Parameters, "parameters", false)
+	m.mergeFields(root, "properties", generated.Properties, "properties", true)
+	if len(m.changes) == 0 {
+		return nil, nil, nil
+	}
+
+	// The encoder drops the document start marker, so the license header is
+	// written back as it was
+	var buf bytes.Buffer
+	if loc := documentStartRegex.FindIndex(content); loc != nil {
+		buf.Write(content[:loc[1]])
+		doc.HeadComment = ""
+		root.HeadComment = ""
+		if len(root.Content) > 0 {
+			root.Content[0].HeadComment = ""
+		}
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), m.changes, nil
+}
+
+type merger struct {
+	changes []string
+}
+
+func (m *merger) change(format string, a ...any) {
+	m.changes = append(m.changes, fmt.Sprintf(format, a...))
+}
+
+// Merges fields into the list under key in the mapping parent. Existing
+// entries are matched on api_name, or name if api_name is not set.
+func (m *merger) mergeFields(parent *yaml.Node, key string, fields []*api.Type, path string, markRemoved bool) {
+	list := mappingValue(parent, key)
+	if list == nil {
+		if len(fields) == 0 {
+			return
+		}
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingValue(parent, key, list)
+	}
+
+	existing := map[string]*yaml.Node{}
+	for _, item := range list.Content {
+		existing[fieldApiName(item)] = item
+	}
+
+	generatedNames := map[string]bool{}
+	for _, f := range fields {
+		generatedNames[f.Name] = true
+		item, ok := existing[f.Name]
+		if !ok {
+			var node yaml.Node
+			if err := node.Encode(f); err != nil {
+				m.change("! %s[%s] could not be added: %v", path, f.Name, err)
+				continue
+			}
+			list.Content = append(list.Content, &node)
+			m.change("+ %s[%s]", path, f.Name)
+			continue
+		}
+		m.mergeField(item, f, fmt.Sprintf("%s[%s]", path, fieldName(item)))
+	}
+
+	if !markRemoved {
+		return
+	}
+	for _, item := range list.Content {
+		if generatedNames[fieldApiName(item)] || strings.Contains(item.HeadComment, removedFieldComment) {
+			continue
+		}
+		if item.HeadComment != "" {
+			item.HeadComment += "\n"
+		}
+		item.HeadComment += "# " + removedFieldComment
+		m.change("- %s[%s] is not in the spec, marked for review", path, fieldName(item))
+	}
+}
+
+// Updates the spec-derived keys of an existing field and merges its nested
+// fields.
+func (m *merger) mergeField(item *yaml.Node, f *api.Type, path string) {
+	if item.Kind != yaml.MappingNode {
+		return
+	}
+
+	if f.Description != "" && f.Description != "No description" {
+		old := mappingValue(item, "description")
+		// Descriptions are often rewrapped by hand
+		if old == nil || strings.Join(strings.Fields(old.Value), " ") != strings.Join(strings.Fields(f.Description), " ") {
+			node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.Description}
+			if strings.Contains(f.Description, "\n") || old == nil || old.Style == yaml.LiteralStyle {
+				node.Style = yaml.LiteralStyle
+			}
+			setMappingValue(item, "description", node)
+			m.change("~ %s.description", path)
+		}
+	}
+
+	flags := []struct {
+		key   string
+		value bool
+	}{{"output", f.Output}, {"immutable", f.Immutable}}
+	for _, flag := range flags {
+		if strings.Contains(item.HeadComment, keepFlagsComment) || hasKeepFlagsComment(item, flag.key) {
+			continue
+		}
+		old := mappingValue(item, flag.key)
+		set := old != nil && old.Value == "true"
+		switch {
+		case flag.value && !set:
+			setMappingValue(item, flag.key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+			m.change("~ %s.%s: true", path, flag.key)
+		case !flag.value && set:
+			deleteMappingValue(item, flag.key)
+			m.change("~ %s.%s: false", path, flag.key)
+		}
+	}
+
+	if old := mappingValue(item, "type"); old != nil && typeKind(old.Value) != typeKind(f.Type) {
+		m.change("! %s.type is %s but the spec has %s, kept", path, old.Value, f.Type)
+	}
+
+	if len(f.Properties) > 0 {
+		m.mergeFields(item, "properties", f.Properties, path+".properties", true)
+	}
+	if f.ItemType != nil && len(f.ItemType.Properties) > 0 {
+		if itemType := mappingValue(item, "item_type"); itemType != nil {
+			m.mergeFields(itemType, "properties", f.ItemType.Properties, path+".item_type.properties", true)
+		}
+	}
+	if f.ValueType != nil && len(f.ValueType.Properties) > 0 {
+		if valueType := mappingValue(item, "value_type"); valueType != nil {
+			m.mergeFields(valueType, "properties", f.ValueType.Properties, path+".value_type.properties", true)
+		}
+	}
+}
+
+// Groups MMv1 types by their shape, so that only changes of shape are
+// reported. For example, a String in the spec is often an Enum or
+// ResourceRef in MMv1.
+func typeKind(t string) string {
+	switch t {
+	case "NestedObject", "Array", "Map":
+		return t
+	case "KeyValuePairs", "KeyValueLabels", "KeyValueAnnotations", "KeyValueTerraformLabels", "KeyValueEffectiveLabels":
+		return "KeyValuePairs"
+	}
+	return "scalar"
+}
+
+// Returns the API name of a field entry.
+func fieldApiName(item *yaml.Node) string {
+	if n := mappingValue(item, "api_name"); n != nil {
+		return n.Value
+	}
+	return fieldName(item)
+}
+
+// Returns the MMv1 name of a field entry, as used in validation paths.
+func fieldName(item *yaml.Node) string {
+	if n := mappingValue(item, "name"); n != nil {
+		return n.Value
+	}
+	return ""
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Returns whether the entry for key in the mapping m has keepFlagsComment on
+// its line.
+func hasKeepFlagsComment(m *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return strings.Contains(m.Content[i].LineComment, keepFlagsComment) || strings.Contains(m.Content[i+1].LineComment, keepFlagsComment)
+		}
+	}
+	return false
+}
+
+// Removes key from the mapping m. Comments above the key move to the next
+// entry.
+func deleteMappingValue(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != key {
+			continue
+		}
+		if head := m.Content[i].HeadComment; head != "" && i+2 < len(m.Content) {
+			next := m.Content[i+2]
+			if next.HeadComment != "" {
+				head += "\n"
+			}
+			next.HeadComment = head + next.HeadComment
+		}
+		m.Content = append(m.Content[:i], m.Content[i+2:]...)
+		return
+	}
+}
+
+// Sets key in the mapping m, keeping its position and comments if it exists.
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			old := m.Content[i+1]
+			value.LineComment = old.LineComment
+			value.HeadComment = old.HeadComment
+			value.FootComment = old.FootComment
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
@@ -0,0 +1,170 @@
+package openapi_generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"golang.org/x/exp/slices"
+)
+
+const existingResource = `# Copyright 2024 Google Inc.
+# Licensed under the Apache License, Version 2.0 (the "License");
+
+---
+name: 'Instance'
+description: |
+  A hand-written description.
+custom_code:
+  pre_create: 'templates/terraform/pre_create/instance.go.tmpl'
+properties:
+  - name: 'displayName'
+    type: String
+    description: |
+      The display name.
+    diff_suppress_func: 'tpgresource.CaseDiffSuppress'
+  - name: 'config'
+    type: NestedObject
+    description: Configuration
+    properties:
+      - name: 'size'
+        type: Integer
+        description: The size.
+  - name: 'zone'
+    api_name: 'location'
+    type: String
+    description: The zone.
+  # Hand-added comment
+  - name: 'legacy'
+    type: String
+    description: Old field.
+examples:
+  - name: 'instance_basic'
+    primary_resource_id: 'example'
+`
+
+func TestMergeResource(t *testing.T) {
+	t.Parallel()
+
+	generated := api.Resource{
+		Name: "Instance",
+		Properties: []*api.Type{
+			{Name: "displayName", Type: "String", Description: "The  display\nname."},
+			{Name: "config", Type: "NestedObject", Description: "Configuration", Properties: []*api.Type{
+				{Name: "size", Type: "Integer", Description: "The size.", Immutable: true},
+				{Name: "tier", Type: "String", Description: "The tier."},
+			}},
+			{Name: "location", Type: "String", Description: "The location of the instance.", Output: true},
+		},
+	}
+
+	merged, changes, err := mergeResource([]byte(existingResource), generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"+ properties[config].properties[tier]",
+		"~ properties[config].properties[size].immutable: true",
+		"~ properties[zone].description",
+		"~ properties[zone].output: true",
+		"- properties[legacy] is not in the spec, marked for review",
+	}
+	slices.Sort(want)
+	slices.Sort(changes)
+	if !slices.Equal(changes, want) {
+		t.Errorf("expected changes\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(changes, "\n"))
+	}
+
+	out := string(merged)
+	for _, kept := range []string{
+		"# Copyright 2024 Google Inc.\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\n---\nname: 'Instance'",
+		"A hand-written description.",
+		"pre_create: 'templates/terraform/pre_create/instance.go.tmpl'",
+		"diff_suppress_func: 'tpgresource.CaseDiffSuppress'",
+		"# Hand-added comment\n  # " + removedFieldComment + "\n  - name: 'legacy'",
+		"name: 'instance_basic'",
+		"The location of the instance.",
+	} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("expected merged resource to contain %q, got\n%s", kept, out)
+		}
+	}
+
+	if merged, changes, err := mergeResource(merged, generated); err != nil || merged != nil {
+		t.Errorf("expected a second merge to change nothing, got %v, %v", changes, err)
+	}
+}
+
+func TestMergeResourceFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		field       string
+		spec        api.Type
+		want        []string
+		wantField   string
+	}{
+		{
+			description: "flags set by the spec",
+			field:       "  - name: 'size'\n    type: Integer\n",
+			spec:        api.Type{Name: "size", Type: "Integer", Output: true, Immutable: true},
+			want:        []string{"~ properties[size].immutable: true", "~ properties[size].output: true"},
+			wantField:   "  - name: 'size'\n    type: Integer\n    output: true\n    immutable: true\n",
+		},
+		{
+			description: "flags unset by the spec",
+			field:       "  - name: 'size'\n    type: Integer\n    output: true\n    immutable: true\n",
+			spec:        api.Type{Name: "size", Type: "Integer"},
+			want:        []string{"~ properties[size].immutable: false", "~ properties[size].output: false"},
+			wantField:   "  - name: 'size'\n    type: Integer\n",
+		},
+		{
+			description: "flag kept by a line comment",
+			field:       "  - name: 'size'\n    type: Integer\n    output: true\n    immutable: true # mmv1-openapi: keep\n",
+			spec:        api.Type{Name: "size", Type: "Integer"},
+			want:        []string{"~ properties[size].output: false"},
+			wantField:   "  - name: 'size'\n    type: Integer\n    immutable: true # mmv1-openapi: keep\n",
+		},
+		{
+			description: "flags kept by a comment above the field",
+			field:       "  # mmv1-openapi: keep\n  - name: 'size'\n    type: Integer\n    immutable: true\n",
+			spec:        api.Type{Name: "size", Type: "Integer", Output: true},
+		},
+		{
+			description: "comments above a removed flag",
+			field:       "  - name: 'size'\n    # Set by the server\n    output: true\n    type: Integer\n",
+			spec:        api.Type{Name: "size", Type: "Integer"},
+			want:        []string{"~ properties[size].output: false"},
+			wantField:   "  - name: 'size'\n    # Set by the server\n    type: Integer\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			content := "name: 'Instance'\nproperties:\n" + tc.field
+			merged, changes, err := mergeResource([]byte(content), api.Resource{Name: "Instance", Properties: []*api.Type{&tc.spec}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.Sort(changes)
+			if !slices.Equal(changes, tc.want) {
+				t.Errorf("expected changes %v, got %v", tc.want, changes)
+			}
+			if tc.want == nil {
+				if merged != nil {
+					t.Errorf("expected no merged content, got\n%s", merged)
+				}
+				return
+			}
+			if want := "name: 'Instance'\nproperties:\n" + tc.wantField; string(merged) != want {
+				t.Errorf("expected merged resource\n%s\ngot\n%s", want, merged)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// An HTTP method of the API that implements one of the standard methods of
+// a resource.
+type method struct {
+	// The OpenAPI path, including the custom verb if there is one
+	Path string
+	// The HTTP method, for example POST
+	Verb string
+	// The custom verb of the path without the colon, for example "delete"
+	// for a path ending in `:delete`
+	CustomVerb string
+	Operation  *openapi3.Operation
+}
+
+// Returns the query parameter of the method with the given name.
+func (m *method) queryParam(name string) *openapi3.Parameter {
+	for _, p := range m.Operation.Parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInQuery && p.Value.Name == name {
+			return p.Value
+		}
+	}
+	return nil
+}
+
+// Reports whether the method returns a long-running operation as described
+// by AIP-151, either through a reference to an Operation schema or a
+// response with the fields of google.longrunning.Operation.
+func (m *method) isLongRunning() bool {
+	if m == nil || m.Operation.Responses == nil {
+		return false
+	}
+	response := m.Operation.Responses.Status(http.StatusOK)
+	if response == nil {
+		response = m.Operation.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return false
+	}
+	content := response.Value.Content.Get("application/json")
+	if content == nil || content.Schema == nil {
+		return false
+	}
+	schema := content.Schema
+	if strings.HasSuffix(schema.Ref, "/Operation") {
+		return true
+	}
+	if schema.Value == nil {
+		return false
+	}
+	props := schema.Value.Properties
+	_, hasName := props["name"]
+	_, hasDone := props["done"]
+	_, hasResponse := props["response"]
+	_, hasError := props["error"]
+	return hasName && hasDone && (hasResponse || hasError)
+}
+
+// The standard methods of a resource found in an OpenAPI document.
+type resourceMethods struct {
+	Name string
+	// The path of the collection that the resource is created in, or the
+	// path of the resource itself for singletons
+	CollectionPath string
+	// A singleton resource exists as long as its parent does. It is read and
+	// updated, but never created or deleted through the API.
+	Singleton bool
+
+	Create *method
+	Read   *method
+	Update *method
+	Delete *method
+
+	// Methods with a custom verb that have no equivalent in MMv1, for example
+	// `POST instances/{instancesId}:restart`
+	CustomMethods []*method
+}
+
+// Returns the custom methods as sorted "VERB path" strings.
+func (r resourceMethods) customMethodNames() []string {
+	var names []string
+	for _, m := range r.CustomMethods {
+		names = append(names, fmt.Sprintf("%s %s", m.Verb, m.Path))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Splits a path such as `/v1/{name}:cancel` into the path without the custom
+// verb and the verb.
+func splitCustomVerb(p string) (string, string) {
+	i := strings.LastIndex(p, ":")
+	if i < 0 || i < strings.LastIndex(p, "/") {
+		return p, ""
+	}
+	return p[:i], p[i+1:]
+}
+
+// Matches the path of an item in a collection, capturing the collection
+var itemPathRegex = regexp.MustCompile(`^(.*)/\{[^/{}]+\}$`)
+
+// Lists of resources are paginated, which distinguishes them from singletons
+func isListMethod(op *openapi3.Operation) bool {
+	for _, p := range op.Parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInQuery && p.Value.Name == "pageToken" {
+			return true
+		}
+	}
+	return false
+}
+
+// Custom verbs that implement a standard method, as used by APIs that cannot
+// use the standard HTTP verbs
+var standardCustomVerbs = map[string]string{
+	"get":    "read",
+	"read":   "read",
+	"update": "update",
+	"patch":  "update",
+	"delete": "delete",
+}
+
+// Finds the resources in the document and the methods that implement them.
+// A resource is either created through a POST on a collection, whose
+// operationId conventionally starts with "Create", or is a singleton that
+// can be read and updated but is not part of a list.
+func findResources(doc *openapi3.T) []*resourceMethods {
+	pathMap := doc.Paths.Map()
+	paths := slices.Sorted(maps.Keys(pathMap))
+
+	// Methods on each path without its custom verb
+	byBase := map[string][]*method{}
+	for _, p := range paths {
+		base, verb := splitCustomVerb(p)
+		for httpVerb, op := range pathMap[p].Operations() {
+			byBase[base] = append(byBase[base], &method{Path: p, Verb: httpVerb, CustomVerb: verb, Operation: op})
+		}
+	}
+	for _, methods := range byBase {
+		slices.SortFunc(methods, func(a, b *method) int {
+			return strings.Compare(a.Path+" "+a.Verb, b.Path+" "+b.Verb)
+		})
+	}
+
+	var resources []*resourceMethods
+	claimed := map[string]bool{}
+	for _, base := range slices.Sorted(maps.Keys(byBase)) {
+		for _, m := range byBase[base] {
+			if m.Verb != http.MethodPost || m.CustomVerb != "" || !strings.HasPrefix(m.Operation.OperationID, "Create") {
+				continue
+			}
+			res := &resourceMethods{
+				Name:           strings.Replace(m.Operation.OperationID, "Create", "", 1),
+				CollectionPath: base,
+				Create:         m,
+			}
+			for _, item := range slices.Sorted(maps.Keys(byBase)) {
+				if match := itemPathRegex.FindStringSubmatch(item); match != nil && match[1] == base {
+					res.addItemMethods(byBase[item])
+					claimed[item] = true
+				}
+			}
+			resources = append(resources, res)
+			claimed[base] = true
+		}
+	}
+
+	// Singletons live at a fixed path below their parent
+	for _, base := range slices.Sorted(maps.Keys(byBase)) {
+		if claimed[base] || itemPathRegex.MatchString(base) {
+			continue
+		}
+		var read *method
+		for _, m := range byBase[base] {
+			if m.Verb == http.MethodGet && m.CustomVerb == "" && !isListMethod(m.Operation) {
+				read = m
+			}
+		}
+		if read == nil || !strings.HasPrefix(read.Operation.OperationID, "Get") {
+			continue
+		}
+		res := &resourceMethods{
+			Name:           strings.Replace(read.Operation.OperationID, "Get", "", 1),
+			CollectionPath: base,
+			Singleton:      true,
+		}
+		res.addItemMethods(byBase[base])
+		if res.Update == nil {
+			// Without an update method this is a read-only view
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	return resources
+}
+
+// Assigns the methods of a resource path to the standard methods they
+// implement, and records the others as custom methods.
+func (r *resourceMethods) addItemMethods(methods []*method) {
+	for _, m := range methods {
+		standard := ""
+		if m.CustomVerb == "" {
+			switch m.Verb {
+			case http.MethodGet:
+				standard = "read"
+			case http.MethodPatch, http.MethodPut:
+				standard = "update"
+			case http.MethodDelete:
+				standard = "delete"
+			}
+		} else {
+			standard = standardCustomVerbs[m.CustomVerb]
+		}
+
+		// Methods on the standard path take precedence over custom verbs
+		switch {
+		case standard == "read" && (r.Read == nil || r.Read.CustomVerb != ""):
+			r.Read = m
+		case standard == "update" && (r.Update == nil || r.Update.CustomVerb != ""):
+			r.Update = m
+		case standard == "delete" && (r.Delete == nil || r.Delete.CustomVerb != ""):
+			r.Delete = m
+		case standard == "" && m.CustomVerb != "":
+			r.CustomMethods = append(r.CustomMethods, m)
+		}
+	}
+}
+
+// Returns the standard methods of the resource that return long-running
+// operations, as MMv1 async actions.
+func (r resourceMethods) longRunningActions() []string {
+	var actions []string
+	if r.Create.isLongRunning() {
+		actions = append(actions, "create")
+	}
+	if r.Delete.isLongRunning() {
+		actions = append(actions, "delete")
+	}
+	if r.Update.isLongRunning() {
+		actions = append(actions, "update")
+	}
+	return actions
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"maps"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -33,6 +34,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/importer"
 	"github.com/getkin/kin-openapi/openapi3"
 	"gopkg.in/yaml.v2"
 )
@@ -40,6 +42,9 @@ import (
 type Parser struct {
 	Folder string
 	Output string
+	// Merge into existing resource YAML instead of overwriting it, keeping
+	// hand edits
+	Merge bool
 }
 
 func NewOpenapiParser(folder, output string) Parser {
@@ -86,22 +91,25 @@ func (parser Parser) WriteYaml(filePath string) {
 	doc, _ := loader.LoadFromFile(filePath)
 	_ = doc.Validate(ctx)
 
-	header, err := os.ReadFile("openapi_generate/header.txt")
-	if err != nil {
-		log.Fatalf("error reading header %v", err)
-	}
+	header := importer.Header
 
 	resourcePaths := findResources(doc)
-	productPath := buildProduct(filePath, parser.Output, doc, header)
+	productPath := buildProduct(filePath, parser.Output, doc, header, parser.Merge)
 
 	// Disables line wrap for long strings
 	yaml.FutureLineWrap()
-	log.Printf("Generated product %+v/product.yaml", productPath)
-	for _, pathArray := range resourcePaths {
-		resource := buildResource(filePath, pathArray[0], pathArray[1], doc)
+	for _, methods := range resourcePaths {
+		resource, unmapped := buildResource(filePath, methods, doc)
 
 		// marshal method
 		resourceOutPathMarshal := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
+		if parser.Merge {
+			if existing, err := os.ReadFile(resourceOutPathMarshal); err == nil {
+				mergeResourceFile(resourceOutPathMarshal, existing, resource)
+				logUnmapped(resource.Name, unmapped)
+				continue
+			}
+		}
 		bytes, err := yaml.Marshal(resource)
 		if err != nil {
 			log.Fatalf("error marshalling yaml %v: %v", resourceOutPathMarshal, err)
@@ -124,30 +132,45 @@ func (parser Parser) WriteYaml(filePath string) {
 			log.Fatalf("error closing resource file %v", err)
 		}
 		log.Printf("Generated resource %s", resourceOutPathMarshal)
+		logUnmapped(resource.Name, unmapped)
 	}
 }
 
-func findResources(doc *openapi3.T) [][]string {
-	var resourcePaths [][]string
-
-	pathMap := doc.Paths.Map()
-	for key, pathValue := range pathMap {
-		if pathValue.Post == nil {
-			continue
-		}
-
-		// Not very clever way of identifying create resource methods
-		if strings.HasPrefix(pathValue.Post.OperationID, "Create") {
-			resourcePath := key
-			resourceName := strings.Replace(pathValue.Post.OperationID, "Create", "", 1)
-			resourcePaths = append(resourcePaths, []string{resourcePath, resourceName})
-		}
+// Merges the generated resource into an existing resource file and prints
+// the changes to stdout.
+func mergeResourceFile(path string, existing []byte, resource api.Resource) {
+	merged, changes, err := mergeResource(existing, resource)
+	if err != nil {
+		log.Fatalf("error merging resource file %v: %v", path, err)
 	}
+	if merged == nil {
+		log.Printf("Resource %s is up to date", path)
+		return
+	}
+	if err := os.WriteFile(path, merged, 0644); err != nil {
+		log.Fatalf("error writing resource file %v", err)
+	}
+	log.Printf("Merged resource %s", path)
+	fmt.Printf("%s:\n", path)
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+}
 
-	return resourcePaths
+func logUnmapped(resourceName string, unmapped []unmappedField) {
+	if len(unmapped) == 0 {
+		return
+	}
+	var report []string
+	for _, u := range unmapped {
+		report = append(report, fmt.Sprintf("  %s: %s", u.Field, u.Reason))
+	}
+	log.Printf("%d field(s) of %s could not be mapped and were left out:\n%s", len(unmapped), resourceName, strings.Join(report, "\n"))
 }
 
-func buildProduct(filePath, output string, root *openapi3.T, header []byte) string {
+// Writes product.yaml and returns the product directory. With merge set, an
+// existing product.yaml is left as it is.
+func buildProduct(filePath, output string, root *openapi3.T, header []byte, merge bool) string {
 
 	version := root.Info.Version
 	server := root.Servers[0].URL
@@ -176,6 +199,10 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	apiProduct.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
 
 	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
+	if _, err := os.Stat(productOutPathMarshal); merge && err == nil {
+		log.Printf("Keeping existing product %s", productOutPathMarshal)
+		return productPath
+	}
 
 	// Default yaml marshaller
 	bytes, err := yaml.Marshal(apiProduct)
@@ -199,6 +226,7 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	if err != nil {
 		log.Fatalf("error closing product file %v", err)
 	}
+	log.Printf("Generated product %s", productOutPathMarshal)
 	return productPath
 }
 
@@ -226,17 +254,28 @@ func stripVersion(path string) string {
 	return re.ReplaceAllString(path, "")
 }
 
-func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T) api.Resource {
+// Builds the resource from its methods. Also returns the fields of the API
+// that could not be represented in MMv1.
+func buildResource(filePath string, methods *resourceMethods, root *openapi3.T) (api.Resource, []unmappedField) {
 	resource := api.Resource{}
-
-	parsedObjects := parseOpenApi(resourcePath, resourceName, root)
-
-	parameters := parsedObjects[0].([]*api.Type)
-	properties := parsedObjects[1].([]*api.Type)
-	queryParam := parsedObjects[2].(string)
-
-	baseUrl := baseUrl(resourcePath)
-	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, google.Underscore(queryParam))
+	resourceName := methods.Name
+
+	b := newTypeBuilder()
+	parameters, properties, queryParam := parseOpenApi(b, methods, root)
+
+	baseUrl := baseUrl(methods.CollectionPath)
+	selfLink := baseUrl
+	if !methods.Singleton {
+		if queryParam == "" {
+			// The name is assigned by the server
+			log.Printf("%s: no ID parameter found in create method, using {{name}} in self_link", resourceName)
+			selfLink = fmt.Sprintf("%s/{{name}}", baseUrl)
+			resource.CreateUrl = baseUrl
+		} else {
+			selfLink = fmt.Sprintf("%s/{{%s}}", baseUrl, google.Underscore(queryParam))
+			resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
+		}
+	}
 
 	resource.Name = resourceName
 	resource.BaseUrl = baseUrl
@@ -245,20 +284,60 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	resource.SelfLink = selfLink
 	resource.IdFormat = selfLink
 	resource.ImportFormat = []string{selfLink}
-	resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
 	resource.Description = "Description"
 
-	resource.AutogenAsync = true
-	async := api.NewAsync()
-	async.Operation.BaseUrl = "{{op_id}}"
-	async.Result.ResourceInsideResponse = true
-	resource.Async = async
+	if actions := methods.longRunningActions(); len(actions) > 0 {
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = actions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = true
+		resource.Async = async
+	}
 
-	if hasUpdate(resourceName, root) {
-		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
+	if read := methods.Read; read == nil {
+		log.Printf("%s: no read method found", resourceName)
 	} else {
+		if read.Verb != http.MethodGet {
+			resource.ReadVerb = read.Verb
+		}
+		if read.CustomVerb != "" {
+			resource.SelfLink = fmt.Sprintf("%s:%s", selfLink, read.CustomVerb)
+		}
+	}
+
+	if update := methods.Update; update == nil {
 		resource.Immutable = true
+	} else {
+		resource.UpdateVerb = update.Verb
+		if update.CustomVerb != "" {
+			resource.UpdateUrl = fmt.Sprintf("%s:%s", selfLink, update.CustomVerb)
+		}
+		if update.queryParam("updateMask") != nil {
+			resource.UpdateMask = true
+		}
+	}
+
+	if methods.Singleton {
+		// Singletons are created by updating them in place
+		resource.CreateVerb = resource.UpdateVerb
+		resource.CreateUrl = resource.UpdateUrl
+		if resource.UpdateMask {
+			resource.CustomCode.PreCreate = "templates/terraform/update_mask.go.tmpl"
+		}
+	}
+
+	if del := methods.Delete; del == nil {
+		resource.ExcludeDelete = true
+	} else if del.CustomVerb != "" || del.Verb != http.MethodDelete {
+		resource.DeleteVerb = del.Verb
+		if del.CustomVerb != "" {
+			resource.DeleteUrl = fmt.Sprintf("%s:%s", selfLink, del.CustomVerb)
+		}
+	}
+
+	if custom := methods.customMethodNames(); len(custom) > 0 {
+		log.Printf("%s: custom methods are not generated: %s", resourceName, strings.Join(custom, ", "))
 	}
 
 	example := r.Examples{}
@@ -273,41 +352,37 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	// copy and pasted without actually using this tool
 	resource.AutogenStatus = base64.StdEncoding.EncodeToString(resourceNameBytes)
 
-	return resource
+	return resource, b.Unmapped
 }
 
-func hasUpdate(resourceName string, root *openapi3.T) bool {
-	// Create and Update have different paths in the OpenAPI spec, so look
-	// through all paths to find one that matches the expected operation name
-	for _, pathValue := range root.Paths.Map() {
-		if pathValue.Patch == nil {
-			continue
-		}
-		if pathValue.Patch.OperationID == fmt.Sprintf("Update%s", resourceName) {
-			return true
-		}
+// Returns the parameters and properties of the resource, and the name of the
+// query parameter that holds the ID of new resources. Singletons are read
+// from their update method as they have no create method.
+func parseOpenApi(b *typeBuilder, methods *resourceMethods, root *openapi3.T) ([]*api.Type, []*api.Type, string) {
+	resourceName := methods.Name
+	source := methods.Create
+	if methods.Singleton {
+		source = methods.Update
 	}
-	return false
-}
-
-func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
-	returnArray := []any{}
-	path := root.Paths.Find(resourcePath)
 
 	parameters := []*api.Type{}
 	var idParam string
-	for _, param := range path.Post.Parameters {
+	for _, param := range source.Operation.Parameters {
+		// Query parameters of updates, such as updateMask, are not fields
+		if methods.Singleton && param.Value.In != openapi3.ParameterInPath {
+			continue
+		}
 		if strings.Contains(strings.ToLower(param.Value.Name), strings.ToLower(resourceName)) {
 			idParam = param.Value.Name
 		}
-		paramObj := writeObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
+		paramObj, ok := b.writeObject(param.Value.Name, google.Underscore(param.Value.Name), param.Value.Schema, true)
 		description := param.Value.Description
 		if strings.TrimSpace(description) == "" {
 			description = "No description"
 		}
-		paramObj.Description = trimDescription(description)
+		paramObj.Description = importer.TrimDescription(description)
 
-		if param.Value.Name == "requestId" || param.Value.Name == "validateOnly" || paramObj.Name == "" {
+		if param.Value.Name == "requestId" || param.Value.Name == "validateOnly" || !ok {
 			continue
 		}
 
@@ -315,106 +390,147 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 		paramObj.Immutable = true
 		parameters = append(parameters, &paramObj)
 	}
+	if methods.Singleton {
+		idParam = ""
+	}
 
-	properties := buildProperties(path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties, path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Required)
+	properties := []*api.Type{}
+	if body := source.Operation.RequestBody; body != nil && body.Value != nil {
+		if content := body.Value.Content.Get("application/json"); content != nil && content.Schema != nil {
+			if v := ResolveSchema(content.Schema); v != nil {
+				properties = b.buildProperties("", v)
+			}
+		}
+	}
 
-	returnArray = append(returnArray, parameters)
-	returnArray = append(returnArray, properties)
-	returnArray = append(returnArray, idParam)
+	return parameters, properties, idParam
+}
 
-	return returnArray
+// A field of the API that has no MMv1 equivalent and was left out of the
+// generated YAML.
+type unmappedField struct {
+	// Dot notation path to the field, for example parent.0.child
+	Field  string
+	Reason string
 }
 
-func propType(prop *openapi3.SchemaRef) openapi3.Types {
-	if len(prop.Value.AllOf) > 0 {
-		return *prop.Value.AllOf[0].Value.Type
-	} else {
-		return *prop.Value.Type
+// Converts OpenAPI schemas to MMv1 types. Fields that cannot be represented
+// are recorded in Unmapped and left out rather than failing.
+type typeBuilder struct {
+	// Breaks $ref cycles
+	recursion importer.RecursionGuard[*openapi3.Schema]
+	Unmapped  []unmappedField
+}
+
+func newTypeBuilder() *typeBuilder {
+	return &typeBuilder{recursion: importer.NewRecursionGuard[*openapi3.Schema]()}
+}
+
+func (b *typeBuilder) unmapped(field, format string, a ...any) {
+	b.Unmapped = append(b.Unmapped, unmappedField{Field: field, Reason: fmt.Sprintf(format, a...)})
+}
+
+// Returns the schema with allOf merged into it. The merged schema is a copy
+// when there is more than one entry, so that refs shared with other fields
+// are left untouched.
+func ResolveSchema(obj *openapi3.SchemaRef) *openapi3.Schema {
+	if obj == nil || obj.Value == nil {
+		return nil
 	}
+	v := obj.Value
+	switch len(v.AllOf) {
+	case 0:
+		return v
+	case 1:
+		return ResolveSchema(v.AllOf[0])
+	}
+	merged := *v
+	merged.AllOf = nil
+	merged.Properties = openapi3.Schemas{}
+	maps.Copy(merged.Properties, v.Properties)
+	merged.Required = slices.Clone(v.Required)
+	for _, part := range v.AllOf {
+		pv := ResolveSchema(part)
+		if pv == nil {
+			continue
+		}
+		if merged.Type == nil {
+			merged.Type = pv.Type
+		}
+		if merged.Format == "" {
+			merged.Format = pv.Format
+		}
+		if merged.Description == "" {
+			merged.Description = pv.Description
+		}
+		merged.Enum = append(merged.Enum, pv.Enum...)
+		if merged.Items == nil {
+			merged.Items = pv.Items
+		}
+		if merged.AdditionalProperties.Schema == nil && merged.AdditionalProperties.Has == nil {
+			merged.AdditionalProperties = pv.AdditionalProperties
+		}
+		maps.Copy(merged.Properties, pv.Properties)
+		merged.Required = append(merged.Required, pv.Required...)
+		merged.ReadOnly = merged.ReadOnly || pv.ReadOnly
+		merged.OneOf = append(merged.OneOf, pv.OneOf...)
+		merged.AnyOf = append(merged.AnyOf, pv.AnyOf...)
+	}
+	return &merged
 }
 
-func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam bool) api.Type {
+// Returns the JSON type of the schema, inferring object and array types from
+// the presence of their keywords when type is omitted.
+func SchemaType(v *openapi3.Schema) string {
+	if v.Type != nil {
+		for _, t := range v.Type.Slice() {
+			if t != openapi3.TypeNull {
+				return t
+			}
+		}
+	}
+	switch {
+	case len(v.Properties) > 0, len(v.OneOf) > 0, len(v.AnyOf) > 0, v.AdditionalProperties.Schema != nil:
+		return openapi3.TypeObject
+	case v.Items != nil:
+		return openapi3.TypeArray
+	}
+	return ""
+}
+
+// Builds the field called name from obj. path is the dot notation path of
+// the field, used for exactly_one_of entries and the unmapped report. Returns
+// false if the field is omitted.
+func (b *typeBuilder) writeObject(name, path string, obj *openapi3.SchemaRef, urlParam bool) (api.Type, bool) {
 	var field api.Type
 
 	switch name {
 	case "projectsId", "project":
 		// projectsId and project are omitted in MMv1 as they are inferred from
 		// the presence of {{project}} in the URL
-		return field
+		return field, false
 	case "locationsId":
 		name = "location"
 	}
-	additionalDescription := ""
 
-	if len(obj.Value.AllOf) > 0 {
-		obj = obj.Value.AllOf[0]
-		objType = *obj.Value.Type
+	v := ResolveSchema(obj)
+	if v == nil {
+		b.unmapped(path, "no schema")
+		return field, false
 	}
 
 	field.Name = name
-	switch objType[0] {
-	case "string":
-		field.Type = "String"
-		if len(obj.Value.Enum) > 0 {
-			var enums []string
-			for _, enum := range obj.Value.Enum {
-				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
-					continue
-				}
-				enums = append(enums, fmt.Sprintf("%v", enum))
-			}
-			additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
-		}
-	case "integer":
-		field.Type = "Integer"
-	case "number":
-		field.Type = "Double"
-	case "boolean":
-		field.Type = "Boolean"
-	case "object":
-		if field.Name == "labels" {
-			// Standard labels implementation
-			field.Type = "KeyValueLabels"
-			break
-		}
-
-		if obj.Value.AdditionalProperties.Schema != nil && obj.Value.AdditionalProperties.Schema.Value.Type.Is("string") {
-			// AdditionalProperties with type string is a string -> string map
-			field.Type = "KeyValuePairs"
-			break
-		}
-
-		field.Type = "NestedObject"
-
-		field.Properties = buildProperties(obj.Value.Properties, obj.Value.Required)
-	case "array":
-		field.Type = "Array"
-		var subField api.Type
-		typ := *obj.Value.Items.Value.Type
-		switch typ[0] {
-		case "string":
-			subField.Type = "String"
-		case "integer":
-			subField.Type = "Integer"
-		case "number":
-			subField.Type = "Double"
-		case "boolean":
-			subField.Type = "Boolean"
-		case "object":
-			subField.Type = "NestedObject"
-			subField.Properties = buildProperties(obj.Value.Items.Value.Properties, obj.Value.Items.Value.Required)
-		}
-		field.ItemType = &subField
-	default:
-		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
+	additionalDescription, ok := b.setType(&field, path, v, false)
+	if !ok {
+		return field, false
 	}
 
-	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
+	description := fmt.Sprintf("%s %s", v.Description, additionalDescription)
 	if strings.TrimSpace(description) == "" {
 		description = "No description"
 	}
 
-	field.Description = trimDescription(description)
+	field.Description = importer.TrimDescription(description)
 
 	if urlParam {
 		field.UrlParamOnly = true
@@ -422,7 +538,7 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	}
 
 	// These methods are only available when the field is set
-	if obj.Value.ReadOnly {
+	if v.ReadOnly {
 		field.Output = true
 	}
 
@@ -438,34 +554,201 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		field.Immutable = true
 	}
 
-	return field
+	return field, true
 }
 
-func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
+// Sets the type of field from v, along with its nested properties, item type
+// or value type. Array items describe enums with enum_values, other fields
+// list the values in the returned description. Returns false if the schema
+// cannot be represented. This is the case for arrays of arrays, as MMv1 has
+// no schema or expander for nested lists; such fields are left out and
+// reported as unmapped.
+func (b *typeBuilder) setType(field *api.Type, path string, v *openapi3.Schema, item bool) (string, bool) {
+	additionalDescription := ""
+
+	switch typ := SchemaType(v); typ {
+	case openapi3.TypeString:
+		field.Type = "String"
+		switch v.Format {
+		case "int32", "int64":
+			field.Type = "Integer"
+		case "date-time", "google-datetime":
+			field.Type = "Time"
+		case "byte":
+			// Bytes are sent as base64-encoded strings in JSON
+			additionalDescription = "A base64-encoded string."
+		}
+		if enumDescription := importer.SetEnumType(field, importer.EnumValues(v.Enum), item); enumDescription != "" {
+			additionalDescription = enumDescription
+		}
+	case openapi3.TypeInteger:
+		field.Type = "Integer"
+	case openapi3.TypeNumber:
+		field.Type = "Double"
+	case openapi3.TypeBoolean:
+		field.Type = "Boolean"
+	case openapi3.TypeObject:
+		if field.Name == "labels" && !item {
+			// Standard labels implementation
+			field.Type = "KeyValueLabels"
+			break
+		}
+		if v.AdditionalProperties.Schema != nil {
+			return additionalDescription, b.setMapType(field, path, v.AdditionalProperties.Schema)
+		}
+		if v.AdditionalProperties.Has != nil && *v.AdditionalProperties.Has {
+			b.unmapped(path, "map with values of any type")
+			return additionalDescription, false
+		}
+		if len(v.Properties) == 0 && len(v.OneOf) == 0 && len(v.AnyOf) == 0 {
+			b.unmapped(path, "object without properties")
+			return additionalDescription, false
+		}
+		if !b.enter(path, v) {
+			return additionalDescription, false
+		}
+		defer b.leave(v)
+
+		field.Type = "NestedObject"
+		field.Properties = b.buildProperties(path+".0.", v)
+	case openapi3.TypeArray:
+		if item {
+			b.unmapped(path, "arrays of arrays cannot be represented in MMv1")
+			return additionalDescription, false
+		}
+		items := ResolveSchema(v.Items)
+		if items == nil {
+			b.unmapped(path, "array without items")
+			return additionalDescription, false
+		}
+		var subField api.Type
+		if _, ok := b.setType(&subField, path, items, true); !ok {
+			return additionalDescription, false
+		}
+		field.Type = "Array"
+		field.ItemType = &subField
+	case "":
+		b.unmapped(path, "schema without a type")
+		return additionalDescription, false
+	default:
+		b.unmapped(path, "unknown type %s", typ)
+		return additionalDescription, false
+	}
+	return additionalDescription, true
+}
+
+// Maps with string values are KeyValuePairs and maps of objects are Map.
+func (b *typeBuilder) setMapType(field *api.Type, path string, value *openapi3.SchemaRef) bool {
+	v := ResolveSchema(value)
+	if v == nil {
+		b.unmapped(path, "map without a value schema")
+		return false
+	}
+	switch SchemaType(v) {
+	case openapi3.TypeString:
+		// AdditionalProperties with type string is a string -> string map
+		field.Type = "KeyValuePairs"
+		return true
+	case openapi3.TypeObject:
+	default:
+		b.unmapped(path, "map with %s values", SchemaType(v))
+		return false
+	}
+	valueType := &api.Type{Name: strings.TrimSuffix(field.Name, "s")}
+	if _, ok := b.setType(valueType, path, v, true); !ok {
+		return false
+	}
+	if valueType.Type != "NestedObject" {
+		b.unmapped(path, "map with %s values", valueType.Type)
+		return false
+	}
+	field.Type = "Map"
+	field.KeyName = "name"
+	field.KeyDescription = "The key of the map entry."
+	field.ValueType = valueType
+	return true
+}
+
+// Records that v is being expanded. Returns false and records the field as
+// unmapped if v is already being expanded importer.MaxRecursionDepth times.
+func (b *typeBuilder) enter(path string, v *openapi3.Schema) bool {
+	if !b.recursion.Enter(v) {
+		log.Printf("Warning: recursive schema at %s truncated after %d levels", path, importer.MaxRecursionDepth)
+		b.unmapped(path, "recursive schema truncated after %d levels", importer.MaxRecursionDepth)
+		return false
+	}
+	return true
+}
+
+func (b *typeBuilder) leave(v *openapi3.Schema) {
+	b.recursion.Leave(v)
+}
+
+// Builds the properties of an object schema. prefix is the path of the
+// object followed by ".0." for nested objects, or empty for the resource.
+// Properties that belong to a oneOf or anyOf group are marked with
+// exactly_one_of or at_least_one_of.
+func (b *typeBuilder) buildProperties(prefix string, v *openapi3.Schema) []*api.Type {
+	props := maps.Clone(v.Properties)
+	if props == nil {
+		props = openapi3.Schemas{}
+	}
+	exactlyOneOf := b.propertyGroup(prefix, "oneOf", v.OneOf, props)
+	atLeastOneOf := b.propertyGroup(prefix, "anyOf", v.AnyOf, props)
+
 	properties := []*api.Type{}
 	for _, k := range slices.Sorted(maps.Keys(props)) {
-		prop := props[k]
-		propObj := writeObject(k, prop, propType(prop), false)
-		if slices.Contains(required, k) {
+		propObj, ok := b.writeObject(k, prefix+google.Underscore(k), props[k], false)
+		if !ok {
+			continue
+		}
+		if slices.Contains(v.Required, k) {
 			propObj.Required = true
 		}
+		if slices.Contains(exactlyOneOf, prefix+google.Underscore(k)) {
+			propObj.ExactlyOneOf = exactlyOneOf
+		}
+		if slices.Contains(atLeastOneOf, prefix+google.Underscore(k)) {
+			propObj.AtLeastOneOf = atLeastOneOf
+		}
 		properties = append(properties, &propObj)
 	}
 	return properties
 }
 
-// Trims whitespace from the ends of lines in a description to force multiline
-// formatting for strings with newlines present
-// Also trim "Output only." and "Required." from descriptions as this gets duplicated
-func trimDescription(description string) string {
-	description, _ = strings.CutPrefix(description, "Optional. ")
-	description, _ = strings.CutPrefix(description, "Output only. ")
-	description, _ = strings.CutPrefix(description, "Required. ")
-	description, _ = strings.CutPrefix(description, "Immutable. ")
-	lines := strings.Split(description, "\n")
-	var trimmedDescription []string
-	for _, line := range lines {
-		trimmedDescription = append(trimmedDescription, strings.Trim(line, " "))
-	}
-	return strings.Join(trimmedDescription, "\n")
+// Returns the paths of the properties that the alternatives of a oneOf or
+// anyOf choose between. Each alternative either requires a single property,
+// as generated from protobuf oneofs, or is a schema whose properties are
+// added to props. Alternatives that do not select a single property are
+// recorded as unmapped, as the constraint cannot be expressed in MMv1.
+func (b *typeBuilder) propertyGroup(prefix, keyword string, alternatives openapi3.SchemaRefs, props openapi3.Schemas) []string {
+	if len(alternatives) == 0 {
+		return nil
+	}
+	var group []string
+	for _, alt := range alternatives {
+		av := ResolveSchema(alt)
+		if av == nil {
+			continue
+		}
+		maps.Copy(props, av.Properties)
+
+		var selected []string
+		switch {
+		case len(av.Required) == 1 && len(av.Properties) <= 1:
+			selected = av.Required
+		case len(av.Properties) == 1:
+			selected = slices.Collect(maps.Keys(av.Properties))
+		}
+		if len(selected) != 1 {
+			b.unmapped(strings.TrimSuffix(prefix, ".0."), "%s alternative does not select a single property; its properties were merged without a constraint", keyword)
+			continue
+		}
+		group = append(group, prefix+google.Underscore(selected[0]))
+	}
+	slices.Sort(group)
+	if len(group) < 2 {
+		return nil
+	}
+	return group
 }
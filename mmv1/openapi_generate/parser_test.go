@@ -0,0 +1,338 @@
+package openapi_generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	"golang.org/x/exp/slices"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: v1
+servers:
+  - url: https://test.googleapis.com
+paths:
+  /v1/projects/{projectsId}/locations/{locationsId}/instances:
+    post:
+      operationId: CreateInstance
+      parameters:
+        - {name: projectsId, in: path, required: true, schema: {type: string}}
+        - {name: locationsId, in: path, required: true, schema: {type: string}}
+        - {name: instanceId, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Instance'}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Operation'}
+    get:
+      operationId: ListInstances
+      parameters:
+        - {name: pageToken, in: query, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+  /v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}:
+    get:
+      operationId: GetInstance
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Instance'}
+    patch:
+      operationId: UpdateInstance
+      parameters:
+        - {name: updateMask, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Operation'}
+  /v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}:delete:
+    post:
+      operationId: DeleteInstance
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: string}
+                  done: {type: boolean}
+                  error: {type: object}
+  /v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}:restart:
+    post:
+      operationId: RestartInstance
+      responses:
+        '200': {description: ok}
+  /v1/projects/{projectsId}/locations/{locationsId}/keys:
+    post:
+      operationId: CreateKey
+      parameters:
+        - {name: keyId, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Instance'}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Instance'}
+  /v1/projects/{projectsId}/locations/{locationsId}/keys/{keysId}:
+    get:
+      operationId: GetKey
+      responses:
+        '200': {description: ok}
+    delete:
+      operationId: DeleteKey
+      responses:
+        '200': {description: ok}
+  /v1/projects/{projectsId}/locations/{locationsId}/config:
+    get:
+      operationId: GetConfig
+      responses:
+        '200': {description: ok}
+    patch:
+      operationId: UpdateConfig
+      parameters:
+        - {name: projectsId, in: path, required: true, schema: {type: string}}
+        - {name: locationsId, in: path, required: true, schema: {type: string}}
+        - {name: updateMask, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Instance'}
+      responses:
+        '200': {description: ok}
+components:
+  schemas:
+    Instance:
+      type: object
+      properties:
+        name: {type: string}
+        description: {type: string}
+    Operation:
+      type: object
+      properties:
+        name: {type: string}
+        done: {type: boolean}
+`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	loader := &openapi3.Loader{Context: context.Background()}
+	doc, err := loader.LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("cannot load test spec: %v", err)
+	}
+	return doc
+}
+
+func TestFindResources(t *testing.T) {
+	t.Parallel()
+
+	doc := loadTestSpec(t)
+	var names []string
+	for _, r := range findResources(doc) {
+		names = append(names, r.Name)
+	}
+	if want := []string{"Instance", "Key", "Config"}; !slices.Equal(names, want) {
+		t.Errorf("expected resources %v, got %v", want, names)
+	}
+}
+
+func TestBuildResource(t *testing.T) {
+	t.Parallel()
+
+	doc := loadTestSpec(t)
+	resources := map[string]*resourceMethods{}
+	for _, r := range findResources(doc) {
+		resources[r.Name] = r
+	}
+
+	instance, _ := buildResource("test_api.yaml", resources["Instance"], doc)
+	if instance.Async == nil || !slices.Equal(instance.Async.Actions, []string{"create", "delete", "update"}) {
+		t.Errorf("expected Instance to be async for create, delete and update, got %#v", instance.Async)
+	}
+	if !instance.UpdateMask || instance.UpdateVerb != "PATCH" {
+		t.Errorf("expected Instance to be updated with PATCH and an update mask, got %q, %v", instance.UpdateVerb, instance.UpdateMask)
+	}
+	if want := "projects/{{project}}/locations/{{location}}/instances/{{instance_id}}:delete"; instance.DeleteUrl != want || instance.DeleteVerb != "POST" {
+		t.Errorf("expected delete with POST %q, got %s %q", want, instance.DeleteVerb, instance.DeleteUrl)
+	}
+	if got := resources["Instance"].customMethodNames(); !slices.Equal(got, []string{"POST /v1/projects/{projectsId}/locations/{locationsId}/instances/{instancesId}:restart"}) {
+		t.Errorf("unexpected custom methods %v", got)
+	}
+
+	key, _ := buildResource("test_api.yaml", resources["Key"], doc)
+	if key.Async != nil || key.AutogenAsync {
+		t.Errorf("expected Key to be synchronous, got %#v", key.Async)
+	}
+	if !key.Immutable || key.DeleteUrl != "" || key.ExcludeDelete {
+		t.Errorf("expected Key to be immutable with a standard delete, got %#v", key)
+	}
+
+	config, _ := buildResource("test_api.yaml", resources["Config"], doc)
+	if want := "projects/{{project}}/locations/{{location}}/config"; config.SelfLink != want || config.CreateUrl != "" {
+		t.Errorf("expected singleton self_link %q and default create_url, got %q and %q", want, config.SelfLink, config.CreateUrl)
+	}
+	if config.CreateVerb != "PATCH" || !config.UpdateMask || !config.ExcludeDelete {
+		t.Errorf("expected Config to be created with PATCH and not deleted, got %#v", config)
+	}
+	if len(config.Parameters) != 1 || config.Parameters[0].Name != "location" {
+		t.Errorf("expected a single location parameter, got %#v", config.Parameters)
+	}
+}
+
+const testSchemas = `
+openapi: 3.0.0
+info: {title: Test API, version: v1}
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        value: {type: string}
+        child: {$ref: '#/components/schemas/Node'}
+    Source:
+      type: object
+      properties:
+        text: {type: string}
+        avro: {type: string}
+      oneOf:
+        - required: [text]
+        - required: [avro]
+    Resource:
+      type: object
+      properties:
+        root: {$ref: '#/components/schemas/Node'}
+        source: {$ref: '#/components/schemas/Source'}
+        configs:
+          type: object
+          additionalProperties: {$ref: '#/components/schemas/Node'}
+        counts:
+          type: object
+          additionalProperties: {type: integer}
+        modes:
+          type: array
+          items: {type: string, enum: [MODE_UNSPECIFIED, ON, OFF]}
+        matrix:
+          type: array
+          items: {type: array, items: {type: string}}
+        size: {type: string, format: int64}
+        data: {type: string, format: byte}
+        createTime: {type: string, format: date-time}
+        anything: {}
+`
+
+func TestBuildProperties(t *testing.T) {
+	t.Parallel()
+
+	loader := &openapi3.Loader{Context: context.Background()}
+	doc, err := loader.LoadFromData([]byte(testSchemas))
+	if err != nil {
+		t.Fatalf("cannot load test spec: %v", err)
+	}
+
+	b := newTypeBuilder()
+	properties := map[string]*api.Type{}
+	for _, p := range b.buildProperties("", doc.Components.Schemas["Resource"].Value) {
+		properties[p.Name] = p
+	}
+
+	cases := []struct {
+		description string
+		name        string
+		check       func(p *api.Type) bool
+	}{
+		{
+			description: "recursive refs are truncated",
+			name:        "root",
+			check: func(p *api.Type) bool {
+				child := p.Properties[0]
+				return child.Name == "child" && len(child.Properties) == 1 && child.Properties[0].Name == "value"
+			},
+		},
+		{
+			description: "oneOf becomes exactly_one_of",
+			name:        "source",
+			check: func(p *api.Type) bool {
+				return slices.Equal(p.Properties[0].ExactlyOneOf, []string{"source.0.avro", "source.0.text"})
+			},
+		},
+		{
+			description: "maps of objects",
+			name:        "configs",
+			check: func(p *api.Type) bool {
+				return p.Type == "Map" && p.KeyName != "" && p.ValueType.Type == "NestedObject"
+			},
+		},
+		{
+			description: "enum arrays",
+			name:        "modes",
+			check: func(p *api.Type) bool {
+				return p.ItemType.Type == "Enum" && slices.Equal(p.ItemType.EnumValues, []string{"ON", "OFF"})
+			},
+		},
+		{
+			description: "int64 format",
+			name:        "size",
+			check:       func(p *api.Type) bool { return p.Type == "Integer" },
+		},
+		{
+			description: "byte format",
+			name:        "data",
+			check: func(p *api.Type) bool {
+				return p.Type == "String" && strings.Contains(p.Description, "base64")
+			},
+		},
+		{
+			description: "date-time format",
+			name:        "createTime",
+			check:       func(p *api.Type) bool { return p.Type == "Time" },
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok := properties[tc.name]
+			if !ok {
+				t.Fatalf("property %s was not generated", tc.name)
+			}
+			if !tc.check(p) {
+				t.Errorf("unexpected property %#v", p)
+			}
+		})
+	}
+
+	var unmapped []string
+	for _, u := range b.Unmapped {
+		unmapped = append(unmapped, u.Field)
+	}
+	slices.Sort(unmapped)
+	want := []string{"anything", "configs.0.child.0.child", "counts", "matrix", "root.0.child.0.child"}
+	if !slices.Equal(unmapped, want) {
+		t.Errorf("expected unmapped fields %v, got %#v", want, b.Unmapped)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Exports the resources and fields of the provider as a single JSON catalog.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The name of the file written to the output directory
+const CatalogFileName = "catalog.json"
+
+// The catalog of every resource generated for a provider version.
+type Catalog struct {
+	Version   string            `json:"version"`
+	Resources []CatalogResource `json:"resources"`
+}
+
+type CatalogResource struct {
+	// The Terraform name, e.g. google_pubsub_topic
+	Name               string         `json:"name"`
+	Product            string         `json:"product"`
+	ApiServiceName     string         `json:"api_service_name"`
+	ApiVersion         string         `json:"api_version,omitempty"`
+	ApiResourceKind    string         `json:"api_resource_type_kind"`
+	Description        string         `json:"description"`
+	MinVersion         string         `json:"min_version"`
+	DeprecationMessage string         `json:"deprecation_message,omitempty"`
+	SourceFile         string         `json:"source_file,omitempty"`
+	ImportFormats      []string       `json:"import_formats,omitempty"`
+	Fields             []CatalogField `json:"fields"`
+}
+
+type CatalogField struct {
+	// The Terraform name, e.g. message_retention_duration
+	Name string `json:"name"`
+	// The dot separated Terraform path, e.g. schema_settings.encoding
+	Path string `json:"path"`
+	// The dot separated API path, empty for fields with no API counterpart
+	ApiField string `json:"api_field,omitempty"`
+	// The MMv1 type, e.g. String or NestedObject
+	Type string `json:"type"`
+	// The MMv1 type of the items of an Array
+	ItemType           string         `json:"item_type,omitempty"`
+	Description        string         `json:"description"`
+	Required           bool           `json:"required"`
+	Optional           bool           `json:"optional"`
+	Computed           bool           `json:"computed"`
+	ForceNew           bool           `json:"force_new,omitempty"`
+	Sensitive          bool           `json:"sensitive,omitempty"`
+	WriteOnly          bool           `json:"write_only,omitempty"`
+	EnumValues         []string       `json:"enum_values,omitempty"`
+	Default            interface{}    `json:"default,omitempty"`
+	MinVersion         string         `json:"min_version"`
+	DeprecationMessage string         `json:"deprecation_message,omitempty"`
+	Fields             []CatalogField `json:"fields,omitempty"`
+}
+
+// A provider backend that writes a JSON catalog of the resources and fields of
+// every product, for tools that need the provider schema without parsing the
+// generated code or docs.
+type TerraformCatalog struct {
+	TargetVersionName string
+
+	Product *api.Product
+
+	StartTime time.Time
+}
+
+func init() {
+	Register(Registration{
+		Name:        "catalog",
+		Description: "JSON catalog of every resource and field",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformCatalog(product, versionName, startTime)
+		},
+	})
+}
+
+func NewTerraformCatalog(product *api.Product, versionName string, startTime time.Time) TerraformCatalog {
+	return TerraformCatalog{
+		Product:           product,
+		TargetVersionName: versionName,
+		StartTime:         startTime,
+	}
+}
+
+// The catalog covers all products, so it is written by CompileCommonFiles.
+func (tc TerraformCatalog) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	return nil
+}
+
+func (tc TerraformCatalog) CopyCommonFiles(outputFolder string, generateCode, generateDocs bool) {
+}
+
+func (tc TerraformCatalog) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
+	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
+	}
+
+	out, err := json.MarshalIndent(NewCatalog(products, tc.TargetVersionName), "", "  ")
+	if err != nil {
+		log.Fatalf("error marshalling catalog: %v", err)
+	}
+	filePath := path.Join(outputFolder, CatalogFileName)
+	if err := os.WriteFile(filePath, append(out, '\n'), 0644); err != nil {
+		log.Fatalf("error writing %s: %v", filePath, err)
+	}
+	log.Printf("Wrote %s", filePath)
+}
+
+// Builds the catalog of the resources of products that exist at versionName.
+func NewCatalog(products []*api.Product, versionName string) Catalog {
+	catalog := Catalog{Version: versionName, Resources: []CatalogResource{}}
+	for _, p := range products {
+		version := p.VersionObjOrClosest(versionName)
+		p.SetPropertiesBasedOnVersion(version)
+		for _, r := range p.Objects {
+			r.ExcludeIfNotInVersion(version)
+			if r.IsExcluded() {
+				continue
+			}
+			catalog.Resources = append(catalog.Resources, catalogResource(r))
+		}
+	}
+	return catalog
+}
+
+func catalogResource(r *api.Resource) CatalogResource {
+	apiVersion := r.ProductMetadata.ServiceVersion()
+	if apiVersion == "" {
+		apiVersion = r.ServiceVersion()
+	}
+	kind := r.ApiResourceTypeKind
+	if kind == "" {
+		kind = r.Name
+	}
+	cr := CatalogResource{
+		Name:               r.TerraformName(),
+		Product:            r.ProductMetadata.Name,
+		ApiServiceName:     r.ProductMetadata.ServiceName(),
+		ApiVersion:         apiVersion,
+		ApiResourceKind:    kind,
+		Description:        strings.TrimSpace(r.Description),
+		MinVersion:         r.MinVersionObj().Name,
+		DeprecationMessage: r.DeprecationMessage,
+		SourceFile:         r.SourceYamlFile,
+		Fields:             catalogFields(google.Concat(r.AllUserProperties(), r.VirtualFields)),
+	}
+	if !r.ExcludeImport {
+		cr.ImportFormats = r.ImportIdFormatsFromResource()
+	}
+	if r.HasProject() {
+		cr.Fields = append(cr.Fields, CatalogField{
+			Name:        "project",
+			Path:        "project",
+			Type:        "String",
+			Description: "The ID of the project in which the resource belongs. If it is not provided, the provider project is used.",
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			MinVersion:  r.MinVersionObj().Name,
+		})
+	}
+	return cr
+}
+
+// Returns the catalog entries of props, in the shape of the Terraform schema:
+// the children of a flattened object are listed in its place.
+func catalogFields(props []*api.Type) []CatalogField {
+	var fields []CatalogField
+	for _, p := range props {
+		if p.Exclude {
+			continue
+		}
+		if p.FlattenObject {
+			fields = append(fields, catalogFields(p.NestedProperties())...)
+			continue
+		}
+		fields = append(fields, catalogField(p))
+	}
+	return fields
+}
+
+func catalogField(p *api.Type) CatalogField {
+	f := CatalogField{
+		Name:               google.Underscore(p.Name),
+		Path:               p.MetadataLineage(),
+		Type:               p.Type,
+		Description:        p.GetDescription(),
+		Required:           p.Required && !p.DefaultFromApi,
+		Optional:           p.DefaultFromApi || (!p.Required && !p.Output),
+		Computed:           p.DefaultFromApi || (!p.Required && p.Output),
+		ForceNew:           p.IsForceNew(),
+		Sensitive:          p.Sensitive,
+		WriteOnly:          p.WriteOnly,
+		EnumValues:         p.EnumValues,
+		Default:            p.DefaultValue,
+		MinVersion:         p.MinVersionObj().Name,
+		DeprecationMessage: p.DeprecationMessage,
+		Fields:             catalogFields(p.NestedProperties()),
+	}
+	if !p.ProviderOnly() {
+		f.ApiField = p.MetadataApiLineage()
+	}
+	if p.IsA("Array") {
+		f.ItemType = p.ItemType.Type
+		f.EnumValues = p.ItemType.EnumValues
+	}
+	return f
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"golang.org/x/exp/slices"
+)
+
+func catalogTestProduct() *api.Product {
+	p := &api.Product{
+		Name: "Pubsub",
+		Versions: []*product.Version{
+			{Name: "ga", BaseUrl: "https://pubsub.googleapis.com/v1/"},
+			{Name: "beta", BaseUrl: "https://pubsub.googleapis.com/v1beta1/"},
+		},
+	}
+	p.Objects = []*api.Resource{
+		{
+			Name:    "Topic",
+			BaseUrl: "projects/{{project}}/topics",
+			Properties: []*api.Type{
+				{Name: "name", Type: "String", Required: true, Immutable: true},
+				{Name: "kmsKeyName", Type: "String", Sensitive: true},
+				{Name: "state", Type: "Enum", Output: true, EnumValues: []string{"ACTIVE", "INGESTION_RESOURCE_ERROR"}},
+				{Name: "preview", Type: "Boolean", MinVersion: "beta", DefaultValue: false},
+				{
+					Name: "schemaSettings",
+					Type: "NestedObject",
+					Properties: []*api.Type{
+						{Name: "encoding", Type: "Enum", DefaultFromApi: true, EnumValues: []string{"JSON", "BINARY"}},
+					},
+				},
+			},
+		},
+		{
+			Name:       "Preview",
+			BaseUrl:    "projects/{{project}}/previews",
+			MinVersion: "beta",
+			Properties: []*api.Type{
+				{Name: "name", Type: "String", Required: true},
+			},
+		},
+	}
+	for _, r := range p.Objects {
+		r.SetDefault(p)
+	}
+	return p
+}
+
+func TestNewCatalog(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description   string
+		version       string
+		wantResources []string
+		wantFields    []string
+	}{
+		{
+			description:   "ga",
+			version:       "ga",
+			wantResources: []string{"google_pubsub_topic"},
+			wantFields:    []string{"name", "kms_key_name", "state", "schema_settings", "project"},
+		},
+		{
+			description:   "beta",
+			version:       "beta",
+			wantResources: []string{"google_pubsub_topic", "google_pubsub_preview"},
+			wantFields:    []string{"name", "kms_key_name", "state", "preview", "schema_settings", "project"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			catalog := NewCatalog([]*api.Product{catalogTestProduct()}, tc.version)
+			var resources []string
+			for _, r := range catalog.Resources {
+				resources = append(resources, r.Name)
+			}
+			if !slices.Equal(resources, tc.wantResources) {
+				t.Fatalf("expected resources %v, got %v", tc.wantResources, resources)
+			}
+			var fields []string
+			for _, f := range catalog.Resources[0].Fields {
+				fields = append(fields, f.Name)
+			}
+			if !slices.Equal(fields, tc.wantFields) {
+				t.Errorf("expected fields %v, got %v", tc.wantFields, fields)
+			}
+		})
+	}
+}
+
+func TestCatalogField(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalog([]*api.Product{catalogTestProduct()}, "beta")
+	topic := catalog.Resources[0]
+	fields := map[string]CatalogField{}
+	for _, f := range topic.Fields {
+		fields[f.Name] = f
+	}
+
+	if got, want := topic.ApiVersion, "v1beta1"; got != want {
+		t.Errorf("expected API version %s, got %s", want, got)
+	}
+	if f := fields["name"]; !f.Required || f.Optional || f.Computed || !f.ForceNew {
+		t.Errorf("expected name to be required and force new, got %+v", f)
+	}
+	if f := fields["kms_key_name"]; !f.Optional || !f.Sensitive || f.ApiField != "kms_key_name" {
+		t.Errorf("expected kms_key_name to be optional and sensitive, got %+v", f)
+	}
+	if f := fields["state"]; !f.Computed || f.Optional || len(f.EnumValues) != 2 {
+		t.Errorf("expected state to be a computed enum, got %+v", f)
+	}
+	if f := fields["preview"]; f.MinVersion != "beta" || f.Default != false {
+		t.Errorf("expected preview to be a beta field with a default, got %+v", f)
+	}
+	if f := fields["project"]; !f.Optional || !f.Computed || f.ApiField != "" {
+		t.Errorf("expected project to be optional and computed, got %+v", f)
+	}
+
+	nested := fields["schema_settings"].Fields
+	if len(nested) != 1 {
+		t.Fatalf("expected one nested field, got %+v", nested)
+	}
+	if f := nested[0]; f.Path != "schema_settings.encoding" || !f.Optional || !f.Computed {
+		t.Errorf("expected schema_settings.encoding to be optional and computed, got %+v", f)
+	}
+}
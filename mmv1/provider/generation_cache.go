@@ -0,0 +1,350 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"golang.org/x/exp/slices"
+)
+
+// Bump when the cache file layout or the hashed inputs change.
+const generationCacheFormat = 2
+
+// Templates that are used by every resource, in addition to the partial
+// templates parsed with every custom template and the files these refer to.
+// Any change to them invalidates the whole cache.
+var sharedTemplateGlobs = []string{
+	"templates/terraform/*.tmpl",
+	"templates/terraform/examples/base_configs/*",
+	"templates/terraform/iam/*",
+}
+
+// Matches paths of templates and handwritten files referenced from YAML,
+// for example custom_code entries and example config paths.
+var referencedFileRegex = regexp.MustCompile(`(?:templates|third_party)/[\w./-]+`)
+
+// A content-hash cache of generated resources. When every input of a resource
+// is unchanged since the last run and the files it produced still exist, the
+// resource is not generated again.
+type GenerationCache struct {
+	path        string
+	overrideDir string
+
+	// Hash of everything shared by all resources: the generator binary, the
+	// shared templates and the generation options
+	sharedHash string
+
+	mu      sync.Mutex
+	entries map[string]generationCacheEntry
+
+	hits, misses int
+}
+
+type generationCacheEntry struct {
+	Hash  string   `json:"hash"`
+	Files []string `json:"files"`
+}
+
+type generationCacheFile struct {
+	Format  int                             `json:"format"`
+	Entries map[string]generationCacheEntry `json:"entries"`
+}
+
+var generationCache *GenerationCache
+
+// Enables the generation cache for this run. The cache is stored in cacheDir
+// in one file per provider and version, and is keyed on the given options in
+// addition to the inputs of each resource.
+func EnableGenerationCache(cacheDir, overrideDir, providerName, version, outputFolder string, generateCode, generateDocs bool) error {
+	sharedHash, err := sharedInputsHash(providerName, version, outputFolder, generateCode, generateDocs)
+	if err != nil {
+		return err
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(providerName))
+	c := &GenerationCache{
+		path:        filepath.Join(cacheDir, fmt.Sprintf("%s_%s.json", name, version)),
+		overrideDir: overrideDir,
+		sharedHash:  sharedHash,
+		entries:     map[string]generationCacheEntry{},
+	}
+
+	content, err := os.ReadFile(c.path)
+	if err == nil {
+		var f generationCacheFile
+		if err := json.Unmarshal(content, &f); err != nil {
+			log.Printf("Ignoring unreadable generation cache %s: %v", c.path, err)
+		} else if f.Format == generationCacheFormat {
+			c.entries = f.Entries
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	log.Printf("Using generation cache %s", c.path)
+	generationCache = c
+	return nil
+}
+
+// Writes the generation cache to disk, if enabled.
+func SaveGenerationCache() error {
+	c := generationCache
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	log.Printf("Generation cache: %d resources regenerated, %d unchanged", c.misses, c.hits)
+
+	content, err := json.MarshalIndent(generationCacheFile{Format: generationCacheFormat, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// Returns the cache key and input hash of a resource, and whether the resource
+// can be skipped because it is unchanged since the last run.
+func (c *GenerationCache) check(productPath string, object api.Resource) (string, string, bool) {
+	key := fmt.Sprintf("%s/%s", productPath, object.Name)
+	hash, err := c.resourceHash(productPath, object)
+	if err != nil {
+		log.Printf("Generation cache: cannot hash %s, regenerating: %v", key, err)
+		return key, "", false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || entry.Hash != hash {
+		return key, hash, false
+	}
+	for _, f := range entry.Files {
+		if _, err := os.Stat(f); err != nil {
+			return key, hash, false
+		}
+	}
+	return key, hash, true
+}
+
+func (c *GenerationCache) record(key, hash string, files []string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.hits++
+		return
+	}
+	c.misses++
+	if hash == "" {
+		delete(c.entries, key)
+		return
+	}
+	sort.Strings(files)
+	c.entries[key] = generationCacheEntry{Hash: hash, Files: files}
+}
+
+// Hashes the YAML of the resource and its product, in both the base and the
+// override directory, the fragments it may include, and every file the
+// resource YAML refers to.
+func (c *GenerationCache) resourceHash(productPath string, object api.Resource) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "shared:%s\n", c.sharedHash)
+
+	var yamlFiles []string
+	yamlFiles = append(yamlFiles, filepath.Join(productPath, "product.yaml"))
+	if object.SourceYamlFile != "" {
+		yamlFiles = append(yamlFiles, object.SourceYamlFile)
+	}
+	if c.overrideDir != "" {
+		yamlFiles = append(yamlFiles, filepath.Join(c.overrideDir, productPath, "product.yaml"))
+	}
+	if object.OverrideYamlFile != "" {
+		yamlFiles = append(yamlFiles, object.OverrideYamlFile)
+		// Overrides of base resources are merged with the base YAML
+		if base, err := filepath.Rel(c.overrideDir, object.OverrideYamlFile); err == nil && base != object.SourceYamlFile {
+			yamlFiles = append(yamlFiles, base)
+		}
+	}
+
+	// Fragments may be included by any of the YAML files
+	var fragments []string
+	for _, f := range yamlFiles {
+		for _, dir := range api.FragmentDirs(f) {
+			matches, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+			if err != nil {
+				return "", err
+			}
+			for _, m := range matches {
+				if !slices.Contains(fragments, m) {
+					fragments = append(fragments, m)
+				}
+			}
+		}
+	}
+	yamlFiles = append(yamlFiles, fragments...)
+
+	referenced := map[string]bool{
+		object.StateMigrationFile(): true,
+	}
+	for _, e := range object.Examples {
+		referenced[e.ConfigPath] = true
+	}
+	for _, f := range yamlFiles {
+		content, err := hashFile(h, f)
+		if err != nil {
+			return "", err
+		}
+		if c.overrideDir != "" {
+			content = bytes.ReplaceAll(content, []byte("{{override_path}}"), []byte(c.overrideDir))
+		}
+		for _, ref := range referencedFileRegex.FindAll(content, -1) {
+			referenced[string(ref)] = true
+			if c.overrideDir != "" {
+				referenced[filepath.Join(c.overrideDir, string(ref))] = true
+			}
+		}
+	}
+
+	var refs []string
+	for ref := range referenced {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if _, err := hashFile(h, ref); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Writes the name and contents of a file to h and returns the contents. Files
+// that do not exist are hashed as missing, so creating them changes the hash.
+func hashFile(h io.Writer, path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(h, "missing:%s\n", path)
+		return nil, nil
+	}
+	if err != nil {
+		// Directories and other unreadable references are not inputs
+		if info, statErr := os.Stat(path); statErr == nil && info.IsDir() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	fmt.Fprintf(h, "file:%s:%d\n", path, len(content))
+	h.Write(content)
+	return content, nil
+}
+
+func sharedInputsHash(providerName, version, outputFolder string, generateCode, generateDocs bool) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "format:%d provider:%s version:%s output:%s code:%t docs:%t\n", generationCacheFormat, providerName, version, outputFolder, generateCode, generateDocs)
+
+	// The generator itself is an input: any change to the Go code rebuilds
+	// the binary and invalidates the cache.
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if _, err := hashFile(h, executable); err != nil {
+		return "", err
+	}
+
+	shared, err := sharedTemplates()
+	if err != nil {
+		return "", err
+	}
+	for _, f := range shared {
+		if _, err := hashFile(h, f); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Returns the sorted paths of the shared templates, including the files they
+// refer to, for example through CustomTemplate, recursively.
+func sharedTemplates() ([]string, error) {
+	seen := map[string]bool{}
+	var queue []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			queue = append(queue, path)
+		}
+	}
+	for _, glob := range sharedTemplateGlobs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			add(m)
+		}
+	}
+	for _, f := range api.PartialTemplates {
+		add(f)
+	}
+
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		content, err := os.ReadFile(f)
+		if err != nil {
+			// Missing files are hashed as missing by the caller
+			continue
+		}
+		for _, ref := range referencedFileRegex.FindAll(content, -1) {
+			add(string(ref))
+		}
+	}
+
+	var shared []string
+	for f := range seen {
+		shared = append(shared, f)
+	}
+	sort.Strings(shared)
+	return shared, nil
+}
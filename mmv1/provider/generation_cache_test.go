@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// chdirTemp changes the working directory to a new temporary directory until
+// the end of the test.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// writeTestFiles writes files relative to the working directory.
+func writeTestFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Not parallel: the shared template globs are relative to the working
+// directory, and the cache is a package variable.
+func TestGenerationCache(t *testing.T) {
+	cases := []struct {
+		description string
+		change      func(t *testing.T)
+		wantHit     bool
+	}{
+		{
+			description: "unchanged inputs",
+			change:      func(t *testing.T) {},
+			wantHit:     true,
+		},
+		{
+			description: "unreferenced template changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"templates/terraform/decoders/other.go.tmpl": "changed"})
+			},
+			wantHit: true,
+		},
+		{
+			description: "shared template changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"templates/terraform/resource.go.tmpl": "changed"})
+			},
+		},
+		{
+			description: "partial template changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl": "changed"})
+			},
+		},
+		{
+			description: "template used by a shared template changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"templates/terraform/custom_flatten/id_from_name.tmpl": "changed"})
+			},
+		},
+		{
+			description: "resource yaml changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"products/foo/Thing.yaml": "name: Thing\ndescription: changed\n"})
+			},
+		},
+		{
+			description: "override yaml changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"overrides/products/foo/Thing.yaml": "description: changed\n"})
+			},
+		},
+		{
+			description: "custom_code file changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"templates/terraform/decoders/thing.go.tmpl": "changed"})
+			},
+		},
+		{
+			description: "fragment changed",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"products/foo/fragments/common.yaml": "changed"})
+			},
+		},
+		{
+			description: "shared fragment added",
+			change: func(t *testing.T) {
+				writeTestFiles(t, map[string]string{"fragments/new.yaml": "added"})
+			},
+		},
+		{
+			description: "recorded output deleted",
+			change: func(t *testing.T) {
+				if err := os.Remove("out/thing.go"); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+
+	object := api.Resource{
+		Name:             "Thing",
+		SourceYamlFile:   "products/foo/Thing.yaml",
+		OverrideYamlFile: "overrides/products/foo/Thing.yaml",
+		ProductMetadata:  &api.Product{Name: "Foo"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			chdirTemp(t)
+			t.Cleanup(func() { generationCache = nil })
+
+			writeTestFiles(t, map[string]string{
+				"templates/terraform/resource.go.tmpl":                          `{{ $.CustomTemplate "templates/terraform/custom_flatten/id_from_name.tmpl" false }}`,
+				"templates/terraform/custom_flatten/id_from_name.tmpl":          "id from name",
+				"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl": "table ref",
+				"templates/terraform/decoders/thing.go.tmpl":                    "decoder",
+				"templates/terraform/decoders/other.go.tmpl":                    "other",
+				"products/foo/product.yaml":                                     "name: Foo\n",
+				"products/foo/Thing.yaml":                                       "name: Thing\ncustom_code:\n  decoder: templates/terraform/decoders/thing.go.tmpl\n",
+				"products/foo/fragments/common.yaml":                            "common",
+				"overrides/products/foo/Thing.yaml":                             "description: override\n",
+				"overrides/templates/terraform/unrelated.tmpl":                  "unrelated",
+			})
+
+			// First run: nothing is cached.
+			if err := EnableGenerationCache("cache", "overrides", "Test", "ga", "out", true, false); err != nil {
+				t.Fatal(err)
+			}
+			key, hash, hit := generationCache.check("products/foo", object)
+			if hit {
+				t.Fatalf("check() on an empty cache = hit, want miss")
+			}
+			writeTestFiles(t, map[string]string{"out/thing.go": "generated"})
+			generationCache.record(key, hash, []string{"out/thing.go"}, hit)
+			if err := SaveGenerationCache(); err != nil {
+				t.Fatal(err)
+			}
+
+			tc.change(t)
+
+			// Second run: loads the saved cache.
+			if err := EnableGenerationCache("cache", "overrides", "Test", "ga", "out", true, false); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, got := generationCache.check("products/foo", object); got != tc.wantHit {
+				t.Errorf("check() hit = %t, want %t", got, tc.wantHit)
+			}
+		})
+	}
+}
+
+func TestGenerationCacheOptions(t *testing.T) {
+	chdirTemp(t)
+	t.Cleanup(func() { generationCache = nil })
+
+	writeTestFiles(t, map[string]string{
+		"products/foo/product.yaml": "name: Foo\n",
+		"products/foo/Thing.yaml":   "name: Thing\n",
+	})
+	object := api.Resource{
+		Name:            "Thing",
+		SourceYamlFile:  "products/foo/Thing.yaml",
+		ProductMetadata: &api.Product{Name: "Foo"},
+	}
+
+	hashWith := func(version string, generateDocs bool) string {
+		t.Helper()
+		if err := EnableGenerationCache("cache", "", "Test", version, "out", true, generateDocs); err != nil {
+			t.Fatal(err)
+		}
+		_, hash, _ := generationCache.check("products/foo", object)
+		if hash == "" {
+			t.Fatalf("check() returned an empty hash")
+		}
+		return hash
+	}
+
+	base := hashWith("ga", false)
+	if got := hashWith("ga", false); got != base {
+		t.Errorf("hash changed between runs with the same options")
+	}
+	if got := hashWith("beta", false); got == base {
+		t.Errorf("hash unchanged for a different version")
+	}
+	if got := hashWith("ga", true); got == base {
+		t.Errorf("hash unchanged when docs are generated")
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Generates a JSON Schema for the configuration of each resource.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// The Terraform meta-arguments that are accepted in every resource block.
+var jsonSchemaMetaArguments = []string{"count", "depends_on", "for_each", "lifecycle", "provider"}
+
+// A subset of JSON Schema draft 2020-12.
+type JSONSchema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+
+	Type    string      `json:"type,omitempty"`
+	Enum    []string    `json:"enum,omitempty"`
+	Pattern string      `json:"pattern,omitempty"`
+	Default interface{} `json:"default,omitempty"`
+
+	Items       *JSONSchema `json:"items,omitempty"`
+	MinItems    int         `json:"minItems,omitempty"`
+	MaxItems    int         `json:"maxItems,omitempty"`
+	UniqueItems bool        `json:"uniqueItems,omitempty"`
+
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	// Either false or a *JSONSchema for the values of a map
+	AdditionalProperties interface{}         `json:"additionalProperties,omitempty"`
+	DependentRequired    map[string][]string `json:"dependentRequired,omitempty"`
+
+	AllOf []*JSONSchema `json:"allOf,omitempty"`
+	AnyOf []*JSONSchema `json:"anyOf,omitempty"`
+	OneOf []*JSONSchema `json:"oneOf,omitempty"`
+	Not   *JSONSchema   `json:"not,omitempty"`
+}
+
+// A provider backend that writes a JSON Schema for the configuration of each
+// resource, to validate configurations converted from HCL to JSON. Nested
+// blocks are arrays of objects, as in the JSON output of HCL converters.
+type TerraformJSONSchema struct {
+	TargetVersionName string
+
+	Version product.Version
+
+	Product *api.Product
+
+	StartTime time.Time
+}
+
+func init() {
+	Register(Registration{
+		Name:        "jsonschema",
+		Description: "JSON Schema of the configuration of every resource",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformJSONSchema(product, versionName, startTime)
+		},
+	})
+}
+
+func NewTerraformJSONSchema(product *api.Product, versionName string, startTime time.Time) TerraformJSONSchema {
+	t := TerraformJSONSchema{
+		Product:           product,
+		TargetVersionName: versionName,
+		Version:           *product.VersionObjOrClosest(versionName),
+		StartTime:         startTime,
+	}
+
+	t.Product.SetPropertiesBasedOnVersion(&t.Version)
+
+	return t
+}
+
+func (t TerraformJSONSchema) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	logger = loggerOrDefault(logger)
+	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating output directory %v: %w", outputFolder, err)
+	}
+
+	for _, object := range t.Product.Objects {
+		object.ExcludeIfNotInVersion(&t.Version)
+
+		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+			logger.Printf("Excluding %s per user request", object.Name)
+			continue
+		}
+		if object.IsExcluded() {
+			continue
+		}
+
+		logger.Printf("Generating %s JSON Schema", object.Name)
+		out, err := json.MarshalIndent(NewResourceJSONSchema(object), "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshalling JSON Schema of %s: %w", object.Name, err)
+		}
+		filePath := path.Join(outputFolder, fmt.Sprintf("%s.schema.json", object.TerraformName()))
+		if err := os.WriteFile(filePath, append(out, '\n'), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+func (t TerraformJSONSchema) CopyCommonFiles(outputFolder string, generateCode, generateDocs bool) {
+}
+
+func (t TerraformJSONSchema) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
+}
+
+// Returns the JSON Schema of the arguments of a resource block.
+func NewResourceJSONSchema(r *api.Resource) *JSONSchema {
+	s := jsonSchemaObject(google.Concat(r.AllUserProperties(), r.VirtualFields), "")
+	s.Schema = jsonSchemaDialect
+	s.Title = r.TerraformName()
+	s.Description = strings.TrimSpace(r.Description)
+	s.Deprecated = r.DeprecationMessage != ""
+
+	if r.HasProject() {
+		s.Properties["project"] = &JSONSchema{
+			Type:        "string",
+			Description: "The ID of the project in which the resource belongs. If it is not provided, the provider project is used.",
+		}
+	}
+	timeouts := map[string]*JSONSchema{
+		"create": {Type: "string"},
+		"delete": {Type: "string"},
+	}
+	if r.Updatable() || r.RootLabels() {
+		timeouts["update"] = &JSONSchema{Type: "string"}
+	}
+	s.Properties["timeouts"] = &JSONSchema{
+		Type:                 "object",
+		Properties:           timeouts,
+		AdditionalProperties: false,
+	}
+	for _, name := range jsonSchemaMetaArguments {
+		s.Properties[name] = &JSONSchema{}
+	}
+	return s
+}
+
+// Returns the JSON Schema of an object with the settable fields of props.
+// prefix is the Terraform schema path of the object, such as `config.0.`, and
+// is used to resolve the constraints between fields.
+func jsonSchemaObject(props []*api.Type, prefix string) *JSONSchema {
+	s := &JSONSchema{
+		Type:                 "object",
+		Properties:           map[string]*JSONSchema{},
+		AdditionalProperties: false,
+	}
+
+	props = jsonSchemaSettableFields(props)
+	names := map[string]string{}
+	for _, p := range props {
+		name := google.Underscore(p.Name)
+		names[prefix+name] = name
+		s.Properties[name] = jsonSchemaField(p, prefix)
+		if p.Required && !p.DefaultFromApi {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	// Each group of fields is listed on every member of the group, and is
+	// only expressible if all the members belong to this object.
+	localNames := func(paths []string) []string {
+		var local []string
+		for _, p := range paths {
+			name, ok := names[p]
+			if !ok {
+				return nil
+			}
+			local = append(local, name)
+		}
+		return local
+	}
+	seen := map[string]bool{}
+	addGroup := func(kind string, group []string) bool {
+		sorted := slices.Clone(group)
+		slices.Sort(sorted)
+		key := kind + ":" + strings.Join(sorted, ",")
+		if len(group) < 2 || seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+	for _, p := range props {
+		name := google.Underscore(p.Name)
+		if group := localNames(p.GetPropertySchemaPathList(p.ExactlyOneOfList())); addGroup("oneOf", group) {
+			s.AllOf = append(s.AllOf, &JSONSchema{OneOf: jsonSchemaRequiredEach(group)})
+		}
+		if group := localNames(p.GetPropertySchemaPathList(p.AtLeastOneOfList())); addGroup("anyOf", group) {
+			s.AllOf = append(s.AllOf, &JSONSchema{AnyOf: jsonSchemaRequiredEach(group)})
+		}
+		for _, other := range localNames(p.GetPropertySchemaPathList(p.Conflicting())) {
+			pair := []string{name, other}
+			if addGroup("not", pair) {
+				s.AllOf = append(s.AllOf, &JSONSchema{Not: &JSONSchema{Required: pair}})
+			}
+		}
+		if with := localNames(p.GetPropertySchemaPathList(p.RequiredWithList())); len(with) > 0 {
+			if s.DependentRequired == nil {
+				s.DependentRequired = map[string][]string{}
+			}
+			s.DependentRequired[name] = with
+		}
+	}
+	return s
+}
+
+// Returns the fields that can be set in configuration, with the children of
+// flattened objects in their place.
+func jsonSchemaSettableFields(props []*api.Type) []*api.Type {
+	var fields []*api.Type
+	for _, p := range props {
+		if p.Exclude || (p.Output && !p.Required && !p.DefaultFromApi) {
+			continue
+		}
+		if p.FlattenObject {
+			fields = append(fields, jsonSchemaSettableFields(p.NestedProperties())...)
+			continue
+		}
+		fields = append(fields, p)
+	}
+	return fields
+}
+
+func jsonSchemaField(p *api.Type, prefix string) *JSONSchema {
+	var s *JSONSchema
+	path := prefix + google.Underscore(p.Name) + ".0."
+	switch {
+	case p.IsA("NestedObject"):
+		s = &JSONSchema{Type: "array", MaxItems: 1, Items: jsonSchemaObject(p.UserProperties(), path)}
+	case p.IsA("Map"):
+		item := jsonSchemaObject(p.ValueType.UserProperties(), path)
+		item.Properties[p.KeyName] = &JSONSchema{Type: "string", Description: strings.TrimSpace(p.KeyDescription)}
+		item.Required = append([]string{p.KeyName}, item.Required...)
+		s = &JSONSchema{Type: "array", UniqueItems: true, Items: item}
+	case p.IsA("Array"):
+		s = &JSONSchema{Type: "array", UniqueItems: p.IsSet}
+		s.MinItems, _ = strconv.Atoi(p.MinSize)
+		s.MaxItems, _ = strconv.Atoi(p.MaxSize)
+		if p.ItemType.IsA("NestedObject") {
+			s.Items = jsonSchemaObject(p.ItemType.UserProperties(), path)
+		} else {
+			s.Items = jsonSchemaPrimitive(p.ItemType)
+			s.Items.Pattern = p.ItemValidation.Regex
+		}
+	case strings.HasPrefix(p.Type, "KeyValue"):
+		s = &JSONSchema{Type: "object", AdditionalProperties: &JSONSchema{Type: "string"}}
+	default:
+		s = jsonSchemaPrimitive(p)
+		s.Pattern = p.Validation.Regex
+	}
+	s.Description = p.GetDescription()
+	s.Deprecated = p.DeprecationMessage != ""
+	s.Default = p.DefaultValue
+	return s
+}
+
+func jsonSchemaPrimitive(p *api.Type) *JSONSchema {
+	switch p.Type {
+	case "Integer":
+		return &JSONSchema{Type: "integer"}
+	case "Double":
+		return &JSONSchema{Type: "number"}
+	case "Boolean":
+		return &JSONSchema{Type: "boolean"}
+	case "Enum":
+		return &JSONSchema{Type: "string", Enum: p.EnumValues}
+	}
+	return &JSONSchema{Type: "string"}
+}
+
+// Returns one schema per name that requires the name, for oneOf and anyOf.
+func jsonSchemaRequiredEach(names []string) []*JSONSchema {
+	var schemas []*JSONSchema
+	for _, name := range names {
+		schemas = append(schemas, &JSONSchema{Required: []string{name}})
+	}
+	return schemas
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"golang.org/x/exp/slices"
+)
+
+func jsonSchemaTestResource() *api.Resource {
+	p := &api.Product{
+		Name:     "Pubsub",
+		Versions: []*product.Version{{Name: "ga", BaseUrl: "https://pubsub.googleapis.com/v1/"}},
+	}
+	r := &api.Resource{
+		Name:    "Subscription",
+		BaseUrl: "projects/{{project}}/subscriptions",
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Required: true, Validation: resource.Validation{Regex: "^[a-z]+$"}},
+			{Name: "state", Type: "Enum", Output: true, EnumValues: []string{"ACTIVE"}},
+			{Name: "ackDeadlineSeconds", Type: "Integer", DefaultFromApi: true},
+			{Name: "filters", Type: "Array", MinSize: "1", MaxSize: "3", ItemType: &api.Type{Type: "String"}},
+			{Name: "pushConfig", Type: "NestedObject", Conflicts: []string{"bigqueryConfig"}, Properties: []*api.Type{
+				{Name: "pushEndpoint", Type: "String", Required: true},
+			}},
+			{Name: "bigqueryConfig", Type: "NestedObject", Conflicts: []string{"pushConfig"}, Properties: []*api.Type{
+				{Name: "table", Type: "String", ExactlyOneOf: []string{"bigqueryConfig.0.table", "bigqueryConfig.0.dataset"}},
+				{Name: "dataset", Type: "String", ExactlyOneOf: []string{"bigqueryConfig.0.table", "bigqueryConfig.0.dataset"}},
+				{Name: "writeMode", Type: "Enum", EnumValues: []string{"APPEND", "TRUNCATE"}, DefaultValue: "APPEND"},
+			}},
+		},
+	}
+	r.SetDefault(p)
+	p.Objects = []*api.Resource{r}
+	return r
+}
+
+func TestNewResourceJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	s := NewResourceJSONSchema(jsonSchemaTestResource())
+
+	if got, want := s.Title, "google_pubsub_subscription"; got != want {
+		t.Errorf("expected title %s, got %s", want, got)
+	}
+	if _, ok := s.Properties["state"]; ok {
+		t.Errorf("expected output field state to be omitted")
+	}
+	for _, name := range []string{"name", "ack_deadline_seconds", "project", "timeouts", "count"} {
+		if _, ok := s.Properties[name]; !ok {
+			t.Errorf("expected property %s", name)
+		}
+	}
+	if !slices.Equal(s.Required, []string{"name"}) {
+		t.Errorf("expected required [name], got %v", s.Required)
+	}
+	if got := s.Properties["name"]; got.Type != "string" || got.Pattern != "^[a-z]+$" {
+		t.Errorf("expected name to be a string with a pattern, got %+v", got)
+	}
+	if got := s.Properties["filters"]; got.Type != "array" || got.MinItems != 1 || got.MaxItems != 3 || got.Items.Type != "string" {
+		t.Errorf("expected filters to be an array of 1 to 3 strings, got %+v", got)
+	}
+	if len(s.AllOf) != 1 || s.AllOf[0].Not == nil || !slices.Equal(s.AllOf[0].Not.Required, []string{"push_config", "bigquery_config"}) {
+		t.Errorf("expected a single conflict between push_config and bigquery_config, got %+v", s.AllOf)
+	}
+
+	bigquery := s.Properties["bigquery_config"]
+	if bigquery.Type != "array" || bigquery.MaxItems != 1 {
+		t.Fatalf("expected bigquery_config to be a block with at most one item, got %+v", bigquery)
+	}
+	if got := bigquery.Items.Properties["write_mode"]; !slices.Equal(got.Enum, []string{"APPEND", "TRUNCATE"}) || got.Default != "APPEND" {
+		t.Errorf("expected write_mode to be an enum with a default, got %+v", got)
+	}
+	if len(bigquery.Items.AllOf) != 1 || len(bigquery.Items.AllOf[0].OneOf) != 2 {
+		t.Errorf("expected exactly one of table and dataset, got %+v", bigquery.Items.AllOf)
+	}
+}
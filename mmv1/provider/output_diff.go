@@ -0,0 +1,196 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/otiai10/copy"
+)
+
+type ChangeType string
+
+const (
+	FileAdded    ChangeType = "A"
+	FileModified ChangeType = "M"
+	FileDeleted  ChangeType = "D"
+)
+
+// A difference between a freshly generated tree and an existing output
+// directory. Path is relative to both roots.
+type FileChange struct {
+	Type ChangeType
+	Path string
+}
+
+// Marks files written by MMv1, either from templates or copied from
+// third_party. Files generated by other tools such as tpgtools are not
+// considered for deletion.
+var mmv1GeneratedFileRegex = regexp.MustCompile(`\*\*\*     AUTO GENERATED CODE    \*\*\*    Type: (MMv1|Handwritten)`)
+
+// The modification time given to the files copied by CopyOutputTree, so that
+// files written by the generator afterwards can be told apart from them.
+var copiedFileTime = time.Unix(0, 0)
+
+// Copies an existing output directory to dst, to generate over. Generating
+// over a copy keeps the module context of the output directory, such as its
+// go.mod, so that tools run on the generated files behave as they would in
+// the output directory. Version control directories are not copied.
+func CopyOutputTree(src, dst string) error {
+	if _, err := os.Stat(src); errors.Is(err, fs.ErrNotExist) {
+		return os.MkdirAll(dst, os.ModePerm)
+	}
+	err := copy.Copy(src, dst, copy.Options{
+		OnSymlink: func(string) copy.SymlinkAction { return copy.Shallow },
+		Skip: func(info os.FileInfo, src, dest string) (bool, error) {
+			return info.IsDir() && info.Name() == ".git", nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(dst, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		return os.Chtimes(p, copiedFileTime, copiedFileTime)
+	})
+}
+
+// Compares a tree generated into generatedRoot with outputRoot. Files only in
+// generatedRoot are added and files whose contents differ are modified. If
+// generatedRoot was created by CopyOutputTree, only the files written since
+// are compared.
+//
+// A file only in outputRoot is reported as deleted when it carries an MMv1
+// generated header and sits in a directory that the generated tree also
+// writes to, so that files owned by other generators or products are left
+// alone. If onlyDirs is not nil, deletions are further limited to its
+// directories, which is needed when only some products were generated:
+// handwritten files are copied to the directory of every product.
+func DiffOutput(generatedRoot, outputRoot string, onlyDirs map[string]bool) ([]FileChange, error) {
+	var changes []FileChange
+	generated := map[string]bool{}
+	generatedDirs := map[string]bool{}
+
+	err := filepath.WalkDir(generatedRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Equal(copiedFileTime) {
+			return nil
+		}
+		rel, err := filepath.Rel(generatedRoot, p)
+		if err != nil {
+			return err
+		}
+		newContent, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		generated[rel] = true
+		generatedDirs[filepath.Dir(rel)] = true
+
+		oldContent, err := os.ReadFile(filepath.Join(outputRoot, rel))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, FileChange{Type: FileAdded, Path: rel})
+		case err != nil:
+			return err
+		case !bytes.Equal(oldContent, newContent):
+			changes = append(changes, FileChange{Type: FileModified, Path: rel})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for dir := range generatedDirs {
+		if onlyDirs != nil && !onlyDirs[dir] {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(outputRoot, dir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			rel := filepath.Join(dir, e.Name())
+			if e.IsDir() || generated[rel] {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(outputRoot, rel))
+			if err != nil {
+				return nil, err
+			}
+			if mmv1GeneratedFileRegex.Match(content) {
+				changes = append(changes, FileChange{Type: FileDeleted, Path: rel})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Writes a unified diff of each change to w, using `diff -u`.
+func WriteOutputDiff(w io.Writer, changes []FileChange, generatedRoot, outputRoot string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if _, err := exec.LookPath("diff"); err != nil {
+		return fmt.Errorf("cannot print the diffs of the dry run, the `diff` command is required: %w", err)
+	}
+
+	for _, c := range changes {
+		oldPath, newPath := filepath.Join(outputRoot, c.Path), filepath.Join(generatedRoot, c.Path)
+		switch c.Type {
+		case FileAdded:
+			oldPath = os.DevNull
+		case FileDeleted:
+			newPath = os.DevNull
+		}
+
+		cmd := exec.Command("diff", "-u", "--label", "a/"+filepath.ToSlash(c.Path), "--label", "b/"+filepath.ToSlash(c.Path), oldPath, newPath)
+		cmd.Stdout = w
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			// diff exits with 1 when the files differ
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+				return fmt.Errorf("error diffing %s: %w", c.Path, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testGeneratedHeader   = "// ***     AUTO GENERATED CODE    ***    Type: MMv1     ***\n"
+	testHandwrittenHeader = "// ***     AUTO GENERATED CODE    ***    Type: Handwritten     ***\n"
+)
+
+func writeFilesUnder(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		p := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiffOutput(t *testing.T) {
+	t.Parallel()
+
+	output := map[string]string{
+		"go.mod":                                 "module example.com/provider\n",
+		"google/services/foo/unchanged.go":       testGeneratedHeader + "unchanged",
+		"google/services/foo/modified.go":        testGeneratedHeader + "old",
+		"google/services/foo/stale.go":           testGeneratedHeader + "stale",
+		"google/services/foo/handwritten.go":     "not generated by MMv1",
+		"google/services/bar/stale.go":           testGeneratedHeader + "other product",
+		"google/services/bar/handwritten.go":     testHandwrittenHeader + "old",
+		"google/tpgresource/stale.go":            testGeneratedHeader + "common",
+		"google/services/foo/scripts/noheader":   "script",
+		"google/services/foo/testdata/nested.go": testGeneratedHeader + "nested directory",
+	}
+	generated := map[string]string{
+		"google/services/foo/unchanged.go":   testGeneratedHeader + "unchanged",
+		"google/services/foo/modified.go":    testGeneratedHeader + "new",
+		"google/services/foo/added.go":       testGeneratedHeader + "added",
+		"google/tpgresource/common.go":       testGeneratedHeader + "common",
+		"google/services/bar/handwritten.go": testHandwrittenHeader + "new",
+	}
+
+	cases := []struct {
+		description string
+		copyOutput  bool
+		onlyDirs    map[string]bool
+		want        []FileChange
+	}{
+		{
+			description: "empty scratch tree",
+			want: []FileChange{
+				{Type: FileModified, Path: "google/services/bar/handwritten.go"},
+				{Type: FileDeleted, Path: "google/services/bar/stale.go"},
+				{Type: FileAdded, Path: "google/services/foo/added.go"},
+				{Type: FileModified, Path: "google/services/foo/modified.go"},
+				{Type: FileDeleted, Path: "google/services/foo/stale.go"},
+				{Type: FileAdded, Path: "google/tpgresource/common.go"},
+				{Type: FileDeleted, Path: "google/tpgresource/stale.go"},
+			},
+		},
+		{
+			description: "copy of the output tree",
+			copyOutput:  true,
+			want: []FileChange{
+				{Type: FileModified, Path: "google/services/bar/handwritten.go"},
+				{Type: FileDeleted, Path: "google/services/bar/stale.go"},
+				{Type: FileAdded, Path: "google/services/foo/added.go"},
+				{Type: FileModified, Path: "google/services/foo/modified.go"},
+				{Type: FileDeleted, Path: "google/services/foo/stale.go"},
+				{Type: FileAdded, Path: "google/tpgresource/common.go"},
+				{Type: FileDeleted, Path: "google/tpgresource/stale.go"},
+			},
+		},
+		{
+			description: "only product directories",
+			copyOutput:  true,
+			onlyDirs:    map[string]bool{"google/services/foo": true},
+			want: []FileChange{
+				{Type: FileModified, Path: "google/services/bar/handwritten.go"},
+				{Type: FileAdded, Path: "google/services/foo/added.go"},
+				{Type: FileModified, Path: "google/services/foo/modified.go"},
+				{Type: FileDeleted, Path: "google/services/foo/stale.go"},
+				{Type: FileAdded, Path: "google/tpgresource/common.go"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			outputRoot := filepath.Join(t.TempDir(), "output")
+			generatedRoot := filepath.Join(t.TempDir(), "output")
+			writeFilesUnder(t, outputRoot, output)
+			if tc.copyOutput {
+				if err := CopyOutputTree(outputRoot, generatedRoot); err != nil {
+					t.Fatal(err)
+				}
+			}
+			writeFilesUnder(t, generatedRoot, generated)
+
+			got, err := DiffOutput(generatedRoot, outputRoot, tc.onlyDirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DiffOutput() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCopyOutputTree(t *testing.T) {
+	t.Parallel()
+
+	src := filepath.Join(t.TempDir(), "output")
+	dst := filepath.Join(t.TempDir(), "output")
+	writeFilesUnder(t, src, map[string]string{
+		"go.mod":         "module example.com/provider\n",
+		"google/foo.go":  "package google\n",
+		".git/HEAD":      "ref: refs/heads/main\n",
+		".github/config": "kept",
+	})
+
+	if err := CopyOutputTree(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"go.mod", "google/foo.go", ".github/config"} {
+		info, err := os.Stat(filepath.Join(dst, path))
+		if err != nil {
+			t.Errorf("%s was not copied: %v", path, err)
+			continue
+		}
+		if !info.ModTime().Equal(copiedFileTime) {
+			t.Errorf("%s has modification time %s, want %s", path, info.ModTime(), copiedFileTime)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, ".git")); !os.IsNotExist(err) {
+		t.Errorf(".git was copied")
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	if err := CopyOutputTree(filepath.Join(t.TempDir(), "nope"), missing); err != nil {
+		t.Fatalf("CopyOutputTree() of a missing directory = %v, want no error", err)
+	}
+	if info, err := os.Stat(missing); err != nil || !info.IsDir() {
+		t.Errorf("CopyOutputTree() of a missing directory did not create %s", missing)
+	}
+}
+
+// Not parallel: the missing diff case changes PATH.
+func TestWriteOutputDiff(t *testing.T) {
+	outputRoot := t.TempDir()
+	generatedRoot := t.TempDir()
+	writeFilesUnder(t, outputRoot, map[string]string{
+		"modified.go": "line 1\nold\n",
+		"deleted.go":  "gone\n",
+	})
+	writeFilesUnder(t, generatedRoot, map[string]string{
+		"modified.go": "line 1\nnew\n",
+		"added.go":    "added\n",
+	})
+	changes := []FileChange{
+		{Type: FileAdded, Path: "added.go"},
+		{Type: FileDeleted, Path: "deleted.go"},
+		{Type: FileModified, Path: "modified.go"},
+	}
+
+	var out bytes.Buffer
+	if err := WriteOutputDiff(&out, changes, generatedRoot, outputRoot); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"--- a/added.go", "+++ b/added.go", "+added",
+		"--- a/deleted.go", "+++ b/deleted.go", "-gone",
+		"--- a/modified.go", "+++ b/modified.go", "-old", "+new",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("WriteOutputDiff() output is missing %q:\n%s", want, out.String())
+		}
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := WriteOutputDiff(&out, changes, generatedRoot, outputRoot); err == nil || !strings.Contains(err.Error(), "`diff` command is required") {
+		t.Errorf("WriteOutputDiff() without diff = %v, want an error naming the diff command", err)
+	}
+	if err := WriteOutputDiff(&out, nil, generatedRoot, outputRoot); err != nil {
+		t.Errorf("WriteOutputDiff() without changes = %v, want no error", err)
+	}
+}
@@ -2,14 +2,26 @@ package provider
 
 import (
 	"fmt"
+	"log"
 	"reflect"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 )
 
+// A generation target. Providers are created through the registry, see
+// Register.
 type Provider interface {
-	Generate(string, string, string, bool, bool)
+	// Generates the files of one product. productPath is the product
+	// directory, such as products/pubsub, and resourceToGenerate optionally
+	// limits generation to a single resource. Messages are logged to logger,
+	// which is not shared with other products being generated.
+	Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error
+
+	// Copies the handwritten files shared across products.
 	CopyCommonFiles(outputFolder string, generateCode, generateDocs bool)
+
+	// Compiles the templates shared across products, such as the provider
+	// resource map, from all products being generated.
 	CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string)
 }
 
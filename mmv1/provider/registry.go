@@ -0,0 +1,128 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"golang.org/x/exp/slices"
+)
+
+// The provider used when --provider is not set
+const DefaultProviderName = "terraform"
+
+// Optional features of the generator that a provider supports.
+type Capabilities struct {
+	// Works with the incremental generation cache, see EnableGenerationCache
+	GenerationCache bool
+
+	// Writes documentation, so --no-docs has an effect
+	Docs bool
+}
+
+// A provider backend that can be selected with --provider.
+//
+// Backends register themselves from an init function. Backends outside of
+// this repository are linked in with a blank import from a file in package
+// main, for example a local `providers_local.go` that is not checked in.
+type Registration struct {
+	Name        string
+	Description string
+
+	Capabilities Capabilities
+
+	// Optional. Adds flags specific to this backend to fs before the command
+	// line is parsed. The backend reads the flag values itself.
+	Flags func(fs *flag.FlagSet)
+
+	// Creates the provider for a product. Called once per product, and once
+	// with an arbitrary product for the files shared across products.
+	New func(product *api.Product, versionName string, startTime time.Time) Provider
+}
+
+var registrations = map[string]Registration{}
+
+// Adds a provider backend to the registry. Registering two backends with the
+// same name panics.
+func Register(r Registration) {
+	if _, ok := registrations[r.Name]; ok {
+		panic(fmt.Sprintf("provider %s registered twice", r.Name))
+	}
+	if r.New == nil {
+		panic(fmt.Sprintf("provider %s has no constructor", r.Name))
+	}
+	registrations[r.Name] = r
+}
+
+// Returns the backend registered under name. An empty name returns the
+// default backend.
+func Lookup(name string) (Registration, error) {
+	if name == "" {
+		name = DefaultProviderName
+	}
+	r, ok := registrations[name]
+	if !ok {
+		return r, fmt.Errorf("unknown provider %q, expected one of %s", name, strings.Join(RegisteredNames(), ", "))
+	}
+	return r, nil
+}
+
+// Returns all registered backends sorted by name.
+func Registrations() []Registration {
+	var all []Registration
+	for _, r := range registrations {
+		all = append(all, r)
+	}
+	slices.SortFunc(all, func(a, b Registration) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return all
+}
+
+// Returns the names of all registered backends, sorted.
+func RegisteredNames() []string {
+	var names []string
+	for _, r := range Registrations() {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// Adds the flags of every registered backend to fs.
+func RegisterFlags(fs *flag.FlagSet) {
+	for _, r := range Registrations() {
+		if r.Flags != nil {
+			r.Flags(fs)
+		}
+	}
+}
+
+// Returns the capabilities of the backend as a comma separated list.
+func (c Capabilities) String() string {
+	var names []string
+	if c.GenerationCache {
+		names = append(names, "cache")
+	}
+	if c.Docs {
+		names = append(names, "docs")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		name        string
+		want        string
+		wantErr     bool
+	}{
+		{
+			description: "default provider",
+			name:        "",
+			want:        DefaultProviderName,
+		},
+		{
+			description: "registered provider",
+			name:        "tgc_next",
+			want:        "tgc_next",
+		},
+		{
+			description: "unknown provider",
+			name:        "nope",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := Lookup(tc.name)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q, got %s", tc.name, r.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Name != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, r.Name)
+			}
+		})
+	}
+}
+
+func TestRegisteredNames(t *testing.T) {
+	t.Parallel()
+
+	want := []string{"catalog", "jsonschema", "oics", "terraform", "tgc", "tgc_cai2hcl", "tgc_next"}
+	if got := RegisteredNames(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a provider twice to panic")
+		}
+	}()
+	Register(Registration{Name: DefaultProviderName, New: registrations[DefaultProviderName].New})
+}
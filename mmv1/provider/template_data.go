@@ -38,6 +38,12 @@ type TemplateData struct {
 	TerraformResourceDirectory string
 	TerraformProviderModule    string
 
+	// If set, the path of every file written is appended to it
+	generatedFiles *[]string
+
+	// If set, messages are logged to it instead of the standard logger
+	Logger *log.Logger
+
 	// TODO rewrite: is this needed?
 	//     # Information about the local environment
 	//     # (which formatters are enabled, start-time)
@@ -68,7 +74,7 @@ func NewTemplateData(outputFolder string, versionName string) *TemplateData {
 	return &td
 }
 
-func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/resource.go.tmpl"
 	templates := []string{
 		templatePath,
@@ -82,58 +88,144 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 		"templates/terraform/nested_query.go.tmpl",
 		"templates/terraform/unordered_list_customize_diff.go.tmpl",
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFrameworkResourceFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/resource_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/framework_property.go.tmpl",
+	}
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateMetadataFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateMetadataFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/metadata.yaml.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
-func (td *TemplateData) GenerateDataSourceFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateDataSourceFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/datasource.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListDataSourceFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/datasource_list.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateProductFile(filePath string, product api.Product) {
+func (td *TemplateData) GenerateListDataSourceDocumentationFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/datasource_list.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateListDataSourceTestFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/examples/base_configs/datasource_list_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := td.testInput(resource)
+	return td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/list_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceDocumentationFile(filePath string, resource api.Resource) error {
+	templatePath := "templates/terraform/list_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceFile(filePath string, ephemeral *api.EphemeralResource) error {
+	templatePath := "templates/terraform/ephemeral_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, ephemeral, true, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceDocumentationFile(filePath string, ephemeral *api.EphemeralResource) error {
+	templatePath := "templates/terraform/ephemeral_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	return td.GenerateFile(filePath, templatePath, ephemeral, false, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceTestFile(filePath string, resource api.Resource, ephemeral *api.EphemeralResource) error {
+	templatePath := "templates/terraform/examples/base_configs/ephemeral_resource_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := td.testInput(resource)
+	tmplInput.Ephemeral = ephemeral
+	return td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+func (td *TemplateData) GenerateProductFile(filePath string, product api.Product) error {
 	templatePath := "templates/terraform/product.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, product, true, templates...)
+	return td.GenerateFile(filePath, templatePath, product, true, templates...)
 }
 
-func (td *TemplateData) GenerateOperationFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateOperationFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/operation.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateDocumentationFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateDocumentationFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/resource.html.markdown.tmpl"
 	templates := []string{
 		templatePath,
 		"templates/terraform/property_documentation.html.markdown.tmpl",
 		"templates/terraform/nested_property_documentation.html.markdown.tmpl",
 	}
-	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
-func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/examples/base_configs/test_file.go.tmpl"
 	templates := []string{
 		"templates/terraform/env_var_context.go.tmpl",
 		templatePath,
 	}
-	tmplInput := TestInput{
+	tmplInput := td.testInput(resource)
+
+	return td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+// Returns the input of the test templates, with placeholder values for the
+// environment variables used in examples.
+func (td *TemplateData) testInput(resource api.Resource) TestInput {
+	return TestInput{
 		Res:                  resource,
 		ImportPath:           td.ImportPath(),
 		PROJECT_NAME:         "my-project-name",
@@ -152,53 +244,51 @@ func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource)
 		CHRONICLE_ID:         "00000000-0000-0000-0000-000000000000",
 		VMWAREENGINE_PROJECT: "my-vmwareengine-project",
 	}
-
-	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
 }
 
-func (td *TemplateData) GenerateIamPolicyFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateIamPolicyFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/iam_policy.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateIamResourceDocumentationFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateIamResourceDocumentationFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/resource_iam.html.markdown.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
-func (td *TemplateData) GenerateIamDatasourceDocumentationFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateIamDatasourceDocumentationFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/datasource_iam.html.markdown.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
-func (td *TemplateData) GenerateIamPolicyTestFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateIamPolicyTestFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/examples/base_configs/iam_test_file.go.tmpl"
 	templates := []string{
 		templatePath,
 		"templates/terraform/env_var_context.go.tmpl",
 		"templates/terraform/iam/iam_test_setup.go.tmpl",
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateSweeperFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateSweeperFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/terraform/sweeper_file.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
-func (td *TemplateData) GenerateTGCResourceFile(templatePath, filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateTGCResourceFile(templatePath, filePath string, resource api.Resource) error {
 	templates := []string{
 		templatePath,
 		"templates/terraform/expand_property_method.go.tmpl",
@@ -210,26 +300,26 @@ func (td *TemplateData) GenerateTGCResourceFile(templatePath, filePath string, r
 		"templates/tgc_next/cai2hcl/flatten_property_method_tgc.go.tmpl",
 		"templates/tgc_next/cai2hcl/full_to_relative_path.go.tmpl",
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateTGCIamResourceFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateTGCIamResourceFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/tgc/resource_converter_iam.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateTGCNextTestFile(filePath string, resource api.Resource) {
+func (td *TemplateData) GenerateTGCNextTestFile(filePath string, resource api.Resource) error {
 	templatePath := "templates/tgc_next/test/test_file.go.tmpl"
 	templates := []string{
 		templatePath,
 	}
-	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+	return td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, goFormat bool, templates ...string) {
+func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, goFormat bool, templates ...string) error {
 	templateFileName := filepath.Base(templatePath)
 
 	funcMap := template.FuncMap{
@@ -241,23 +331,23 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 
 	tmpl, err := template.New(templateFileName).Funcs(funcMap).ParseFiles(templates...)
 	if err != nil {
-		glog.Exit(fmt.Sprintf("error parsing %s for filepath %s ", templateFileName, filePath), err)
+		return fmt.Errorf("error parsing %s for filepath %s: %w", templateFileName, filePath, err)
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, input); err != nil {
-		glog.Exit(fmt.Sprintf("error executing %s for filepath %s ", templateFileName, filePath), err)
+		return fmt.Errorf("error executing %s for filepath %s: %w", templateFileName, filePath, err)
 	}
 
 	sourceByte := contents.Bytes()
 	if len(sourceByte) == 0 {
-		return
+		return nil
 	}
 
 	if goFormat {
 		formattedByte, err := format.Source(sourceByte)
 		if err != nil {
-			glog.Error(fmt.Errorf("error formatting %s: %s", filePath, err))
+			loggerOrDefault(td.Logger).Print(fmt.Errorf("error formatting %s: %s", filePath, err))
 		} else {
 			sourceByte = formattedByte
 		}
@@ -266,10 +356,34 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 		}
 	}
 
-	err = os.WriteFile(filePath, sourceByte, 0644)
-	if err != nil {
-		glog.Exit(err)
+	if err := os.WriteFile(filePath, sourceByte, 0644); err != nil {
+		return err
 	}
+	if td.generatedFiles != nil {
+		*td.generatedFiles = append(*td.generatedFiles, filePath)
+	}
+	return nil
+}
+
+// Returns logger, or the standard logger if logger is nil.
+func loggerOrDefault(logger *log.Logger) *log.Logger {
+	if logger != nil {
+		return logger
+	}
+	return log.Default()
+}
+
+// Returns the directories, relative to outputPath, that files were compiled
+// into from product templates during this run.
+func ProductOutputDirs(outputPath string) map[string]bool {
+	dirs := map[string]bool{}
+	goimportFiles.Range(func(filePath, _ any) bool {
+		if rel, err := filepath.Rel(outputPath, filepath.Dir(filePath.(string))); err == nil {
+			dirs[rel] = true
+		}
+		return true
+	})
+	return dirs
 }
 
 func (td *TemplateData) ImportPath() string {
@@ -321,6 +435,7 @@ func FixImports(outputPath string, dumpDiffs bool) {
 
 type TestInput struct {
 	Res                  api.Resource
+	Ephemeral            *api.EphemeralResource
 	ImportPath           string
 	PROJECT_NAME         string
 	CREDENTIALS          string
@@ -41,6 +41,17 @@ type Terraform struct {
 
 	ResourcesForVersion []map[string]string
 
+	// The generated ephemeral resources, with the keys Service and FuncName
+	EphemeralResourcesForVersion []map[string]string
+
+	// The generated list resources, with the keys Service and FuncName
+	ListResourcesForVersion []map[string]string
+
+	// The generated plugin-framework resources, with the keys Service and
+	// FuncName. They are registered in the framework provider instead of
+	// ResourcesForVersion.
+	FrameworkResourcesForVersion []map[string]string
+
 	TargetVersionName string
 
 	Version product.Version
@@ -48,6 +59,23 @@ type Terraform struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Directory of the product's YAML, for example "products/pubsub"
+	productDirectory string
+
+	// Logger of the product being generated, see Logger
+	logger *log.Logger
+}
+
+func init() {
+	Register(Registration{
+		Name:         DefaultProviderName,
+		Description:  "terraform-provider-google and terraform-provider-google-beta",
+		Capabilities: Capabilities{GenerationCache: true, Docs: true},
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraform(product, versionName, startTime)
+		},
+	})
 }
 
 func NewTerraform(product *api.Product, versionName string, startTime time.Time) Terraform {
@@ -70,89 +98,189 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 	return t
 }
 
-func (t Terraform) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+func (t Terraform) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
 	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
+		return fmt.Errorf("error creating output directory %v: %w", outputFolder, err)
 	}
 
-	t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	t.productDirectory = productPath
+	t.logger = logger
+	if err := t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs); err != nil {
+		return err
+	}
 
 	if generateCode {
-		t.GenerateProduct(outputFolder)
-		t.GenerateOperation(outputFolder)
+		if err := t.GenerateProduct(outputFolder); err != nil {
+			return err
+		}
+		if err := t.GenerateOperation(outputFolder); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	for _, object := range t.Product.Objects {
 		object.ExcludeIfNotInVersion(&t.Version)
 
 		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
+			t.Logger().Printf("Excluding %s per user request", object.Name)
 			continue
 		}
 
-		t.GenerateObject(*object, outputFolder, t.TargetVersionName, generateCode, generateDocs)
+		if err := t.GenerateObject(*object, outputFolder, t.TargetVersionName, generateCode, generateDocs); err != nil {
+			return fmt.Errorf("%s: %w", object.Name, err)
+		}
 	}
+	return nil
 }
 
-func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) {
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) (err error) {
+	templateData := t.newTemplateData(outputFolder)
+
+	if generationCache != nil {
+		key, hash, hit := generationCache.check(t.productDirectory, object)
+		if hit {
+			t.Logger().Printf("Skipping unchanged %s resource", object.Name)
+			generationCache.record(key, hash, nil, true)
+			return nil
+		}
+		files := []string{}
+		templateData.generatedFiles = &files
+		defer func() {
+			// A resource that failed is generated again on the next run
+			if err != nil {
+				hash = ""
+			}
+			generationCache.record(key, hash, files, false)
+		}()
+	}
 
 	if !object.IsExcluded() {
-		log.Printf("Generating %s resource", object.Name)
-		t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.Logger().Printf("Generating %s resource", object.Name)
+		if err := t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs); err != nil {
+			return err
+		}
 
 		if generateCode {
-			// log.Printf("Generating %s tests", object.Name)
-			t.GenerateResourceTests(object, *templateData, outputFolder)
-			t.GenerateResourceSweeper(object, *templateData, outputFolder)
-			t.GenerateSingularDataSource(object, *templateData, outputFolder)
-			// log.Printf("Generating %s metadata", object.Name)
-			t.GenerateResourceMetadata(object, *templateData, outputFolder)
+			for _, generate := range []func(api.Resource, TemplateData, string) error{
+				t.GenerateResourceTests,
+				t.GenerateResourceSweeper,
+				t.GenerateSingularDataSource,
+				t.GenerateListDataSource,
+				t.GenerateEphemeralResources,
+				t.GenerateListResource,
+				t.GenerateResourceMetadata,
+			} {
+				if err := generate(object, *templateData, outputFolder); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	// if iam_policy is not defined or excluded, don't generate it
 	if object.IamPolicy == nil || object.IamPolicy.Exclude {
-		return
+		return nil
 	}
 
-	t.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
+	return t.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
+}
+
+// Returns the logger of the product being generated, or the standard logger.
+func (t *Terraform) Logger() *log.Logger {
+	return loggerOrDefault(t.logger)
+}
+
+// Returns template data that logs to the logger of the product.
+func (t *Terraform) newTemplateData(outputFolder string) *TemplateData {
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	templateData.Logger = t.logger
+	return templateData
 }
 
-func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	if generateCode {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+			return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
-		templateData.GenerateResourceFile(targetFilePath, object)
+		if reason := object.IdentityExclusion(); reason != "" {
+			t.Logger().Printf("%s has no resource identity: %s", object.Name, reason)
+		}
+		if object.PluginFramework {
+			if err := templateData.GenerateFrameworkResourceFile(targetFilePath, object); err != nil {
+				return err
+			}
+		} else {
+			if err := templateData.GenerateResourceFile(targetFilePath, object); err != nil {
+				return err
+			}
+		}
 	}
 
 	if generateDocs {
 		targetFolder := path.Join(outputFolder, "website", "docs", "r")
 		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+			return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
-		templateData.GenerateDocumentationFile(targetFilePath, object)
+		if err := templateData.GenerateDocumentationFile(targetFilePath, object); err != nil {
+			return err
+		}
+
+		if object.ShouldGenerateListDataSource() {
+			targetFolder := path.Join(outputFolder, "website", "docs", "d")
+			if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+				return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+			}
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", strings.TrimPrefix(object.ListDataSourceName(), "google_")))
+			if err := templateData.GenerateListDataSourceDocumentationFile(targetFilePath, object); err != nil {
+				return err
+			}
+		}
+
+		if object.ShouldGenerateListResource() {
+			targetFolder := path.Join(outputFolder, "website", "docs", "list-resources")
+			if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+				return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+			}
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+			if err := templateData.GenerateListResourceDocumentationFile(targetFilePath, object); err != nil {
+				return err
+			}
+		}
+
+		if len(object.EphemeralResources) > 0 {
+			targetFolder := path.Join(outputFolder, "website", "docs", "ephemeral-resources")
+			if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+				return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+			}
+			for _, e := range object.EphemeralResources {
+				targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", strings.TrimPrefix(e.Name, "google_")))
+				if err := templateData.GenerateEphemeralResourceDocumentationFile(targetFilePath, e); err != nil {
+					return err
+				}
+			}
+		}
 	}
+	return nil
 }
 
-func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) {
+func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) error {
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_meta.yaml", t.FullResourceName(object)))
-	templateData.GenerateMetadataFile(targetFilePath, object)
+	return templateData.GenerateMetadataFile(targetFilePath, object)
 }
 
-func (t *Terraform) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
+func (t *Terraform) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) error {
 	eligibleExample := false
 	for _, example := range object.Examples {
 		if !example.ExcludeTest {
@@ -163,89 +291,162 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 		}
 	}
 	if !eligibleExample {
-		return
+		return nil
 	}
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_test.go", t.ResourceGoFilename(object)))
-	templateData.GenerateTestFile(targetFilePath, object)
+	return templateData.GenerateTestFile(targetFilePath, object)
 }
 
-func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData TemplateData, outputFolder string) {
+func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData TemplateData, outputFolder string) error {
 	if !object.ShouldGenerateSweepers() {
-		return
+		return nil
 	}
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_sweeper.go", t.ResourceGoFilename(object)))
-	templateData.GenerateSweeperFile(targetFilePath, object)
+	return templateData.GenerateSweeperFile(targetFilePath, object)
 }
 
-func (t *Terraform) GenerateSingularDataSource(object api.Resource, templateData TemplateData, outputFolder string) {
+func (t *Terraform) GenerateSingularDataSource(object api.Resource, templateData TemplateData, outputFolder string) error {
 	if !object.ShouldGenerateSingularDataSource() {
-		return
+		return nil
 	}
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
-	templateData.GenerateDataSourceFile(targetFilePath, object)
+	return templateData.GenerateDataSourceFile(targetFilePath, object)
+}
+
+// Generates the plural data source of a resource, and its acceptance test if
+// the resource has a testable example.
+func (t *Terraform) GenerateListDataSource(object api.Resource, templateData TemplateData, outputFolder string) error {
+	if !object.ShouldGenerateListDataSource() {
+		return nil
+	}
+
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+	}
+	fileName := strings.TrimPrefix(object.ListDataSourceName(), "google_")
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", fileName))
+	if err := templateData.GenerateListDataSourceFile(targetFilePath, object); err != nil {
+		return err
+	}
+
+	if object.FixtureTestExample() != nil {
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_generated_test.go", fileName))
+		if err := templateData.GenerateListDataSourceTestFile(targetFilePath, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generates the list resource of a resource, used by Terraform `list` blocks.
+func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, outputFolder string) error {
+	if !object.ShouldGenerateListResource() {
+		return nil
+	}
+
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("list_resource_%s.go", t.ResourceGoFilename(object)))
+	return templateData.GenerateListResourceFile(targetFilePath, object)
+}
+
+// Generates the ephemeral resources of a resource, and their acceptance tests
+// if the resource has a testable example.
+func (t *Terraform) GenerateEphemeralResources(object api.Resource, templateData TemplateData, outputFolder string) error {
+	if len(object.EphemeralResources) == 0 {
+		return nil
+	}
+
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
+	}
+	for _, e := range object.EphemeralResources {
+		fileName := strings.TrimPrefix(e.Name, "google_")
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s.go", fileName))
+		if err := templateData.GenerateEphemeralResourceFile(targetFilePath, e); err != nil {
+			return err
+		}
+
+		if object.FixtureTestExample() != nil {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s_generated_test.go", fileName))
+			if err := templateData.GenerateEphemeralResourceTestFile(targetFilePath, object, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // GenerateProduct creates the product.go file for a given service directory.
 // This will be used to seed the directory and add a package-level comment
 // specific to the product.
-func (t *Terraform) GenerateProduct(outputFolder string) {
+func (t *Terraform) GenerateProduct(outputFolder string) error {
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 
 	targetFilePath := path.Join(targetFolder, "product.go")
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
-	templateData.GenerateProductFile(targetFilePath, *t.Product)
+	templateData := t.newTemplateData(outputFolder)
+	return templateData.GenerateProductFile(targetFilePath, *t.Product)
 }
 
-func (t *Terraform) GenerateOperation(outputFolder string) {
+func (t *Terraform) GenerateOperation(outputFolder string) error {
 	asyncObjects := google.Select(t.Product.Objects, func(o *api.Resource) bool {
 		return o.AutogenAsync
 	})
 
 	if len(asyncObjects) == 0 {
-		return
+		return nil
 	}
 
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_operation.go", google.Underscore(t.Product.Name)))
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
-	templateData.GenerateOperationFile(targetFilePath, *asyncObjects[0])
+	templateData := t.newTemplateData(outputFolder)
+	return templateData.GenerateOperationFile(targetFilePath, *asyncObjects[0])
 }
 
 // Generate the IAM policy for this object. This is used to query and test
 // IAM policies separately from the resource itself
-func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	if generateCode && object.IamPolicy != nil && (object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName)) {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
 		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+			return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s.go", t.ResourceGoFilename(object)))
-		templateData.GenerateIamPolicyFile(targetFilePath, object)
+		if err := templateData.GenerateIamPolicyFile(targetFilePath, object); err != nil {
+			return err
+		}
 
 		// Only generate test if testable examples exist.
 		examples := google.Reject(object.Examples, func(e resource.Examples) bool {
@@ -253,28 +454,33 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 		})
 		if len(examples) != 0 {
 			targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s_generated_test.go", t.ResourceGoFilename(object)))
-			templateData.GenerateIamPolicyTestFile(targetFilePath, object)
+			if err := templateData.GenerateIamPolicyTestFile(targetFilePath, object); err != nil {
+				return err
+			}
 		}
 	}
 	if generateDocs {
-		t.GenerateIamDocumentation(object, templateData, outputFolder, generateCode, generateDocs)
+		return t.GenerateIamDocumentation(object, templateData, outputFolder, generateCode, generateDocs)
 	}
+	return nil
 }
 
-func (t *Terraform) GenerateIamDocumentation(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (t *Terraform) GenerateIamDocumentation(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	resourceDocFolder := path.Join(outputFolder, "website", "docs", "r")
 	if err := os.MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourceDocFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", resourceDocFolder, err)
 	}
 	targetFilePath := path.Join(resourceDocFolder, fmt.Sprintf("%s_iam.html.markdown", t.FullResourceName(object)))
-	templateData.GenerateIamResourceDocumentationFile(targetFilePath, object)
+	if err := templateData.GenerateIamResourceDocumentationFile(targetFilePath, object); err != nil {
+		return err
+	}
 
 	datasourceDocFolder := path.Join(outputFolder, "website", "docs", "d")
 	if err := os.MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", datasourceDocFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", datasourceDocFolder, err)
 	}
 	targetFilePath = path.Join(datasourceDocFolder, fmt.Sprintf("%s_iam_policy.html.markdown", t.FullResourceName(object)))
-	templateData.GenerateIamDatasourceDocumentationFile(targetFilePath, object)
+	return templateData.GenerateIamDatasourceDocumentationFile(targetFilePath, object)
 }
 
 // Finds the folder name for a given version of the terraform provider
@@ -534,7 +740,9 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
+		if err := fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...); err != nil {
+			log.Fatal(err)
+		}
 		// continue to next file if no file was generated
 		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
 			continue
@@ -776,6 +984,20 @@ func (t Terraform) GetMmv1ServicesInVersion(products []*api.Product) []string {
 	return services
 }
 
+// Returns the services of the generated plugin-framework, ephemeral and list
+// resources, sorted and without duplicates. Used to import them in the
+// framework provider.
+func (t Terraform) FrameworkResourceServices() []string {
+	var services []string
+	for _, e := range google.Concat(google.Concat(t.FrameworkResourcesForVersion, t.EphemeralResourcesForVersion), t.ListResourcesForVersion) {
+		if !slices.Contains(services, e["Service"]) {
+			services = append(services, e["Service"])
+		}
+	}
+	slices.Sort(services)
+	return services
+}
+
 // # Generates the list of resources, and gets the count of resources and iam resources
 // # dependent on the version ga, beta or private.
 // # The resource object has the format
@@ -783,6 +1005,8 @@ func (t Terraform) GetMmv1ServicesInVersion(products []*api.Product) []string {
 // #    terraform_name:
 // #    resource_name:
 // #    iam_class_name:
+// #    list_data_source_tf_name:
+// #    list_data_source_func_name:
 // # }
 // # The variable resources_for_version is used to generate resources in file
 // # mmv1/third_party/terraform/provider/provider_mmv1_resources.go.erb
@@ -794,11 +1018,21 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
-			var resourceName string
+			var resourceName, listDataSourceName string
 
 			if !object.IsExcluded() {
 				t.ResourceCount++
-				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				if object.PluginFramework {
+					t.FrameworkResourcesForVersion = append(t.FrameworkResourcesForVersion, map[string]string{
+						"Service":  service,
+						"FuncName": fmt.Sprintf("%s.Resource%s", service, object.ResourceName()),
+					})
+				} else {
+					resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				}
+				if object.ShouldGenerateListDataSource() {
+					listDataSourceName = fmt.Sprintf("%s.DataSource%s", service, object.ListDataSourceResourceName())
+				}
 			}
 
 			var iamClassName string
@@ -811,10 +1045,27 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				}
 			}
 
+			if !object.IsExcluded() {
+				if object.ShouldGenerateListResource() {
+					t.ListResourcesForVersion = append(t.ListResourcesForVersion, map[string]string{
+						"Service":  service,
+						"FuncName": fmt.Sprintf("%s.ListResource%s", service, object.ResourceName()),
+					})
+				}
+				for _, e := range object.EphemeralResources {
+					t.EphemeralResourcesForVersion = append(t.EphemeralResourcesForVersion, map[string]string{
+						"Service":  service,
+						"FuncName": fmt.Sprintf("%s.Ephemeral%s", service, e.GoName()),
+					})
+				}
+			}
+
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":          object.TerraformName(),
+				"ResourceName":           resourceName,
+				"IamClassName":           iamClassName,
+				"ListDataSourceTfName":   object.ListDataSourceName(),
+				"ListDataSourceFuncName": listDataSourceName,
 			})
 		}
 	}
@@ -34,6 +34,20 @@ type TerraformOiCS struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Logger of the product being generated
+	logger *log.Logger
+}
+
+func init() {
+	Register(Registration{
+		Name:         "oics",
+		Description:  "Open in Cloud Shell examples",
+		Capabilities: Capabilities{Docs: true},
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformOiCS(product, versionName, startTime)
+		},
+	})
 }
 
 func NewTerraformOiCS(product *api.Product, versionName string, startTime time.Time) TerraformOiCS {
@@ -49,35 +63,41 @@ func NewTerraformOiCS(product *api.Product, versionName string, startTime time.T
 	return toics
 }
 
-func (toics TerraformOiCS) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
-	toics.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+func (toics TerraformOiCS) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	toics.logger = loggerOrDefault(logger)
+	return toics.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
 }
 
-func (toics TerraformOiCS) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (toics TerraformOiCS) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	for _, object := range toics.Product.Objects {
 		object.ExcludeIfNotInVersion(&toics.Version)
 
 		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
+			toics.logger.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
 
-		toics.GenerateObject(*object, outputFolder, toics.TargetVersionName, generateCode, generateDocs)
+		if err := toics.GenerateObject(*object, outputFolder, toics.TargetVersionName, generateCode, generateDocs); err != nil {
+			return fmt.Errorf("%s: %w", object.Name, err)
+		}
 	}
+	return nil
 }
 
-func (toics TerraformOiCS) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (toics TerraformOiCS) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	templateData := NewTemplateData(outputFolder, toics.TargetVersionName)
+	templateData.Logger = toics.logger
 
 	if !object.IsExcluded() {
-		log.Printf("Generating %s resource", object.Name)
-		toics.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		toics.logger.Printf("Generating %s resource", object.Name)
+		return toics.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
 	}
+	return nil
 }
 
-func (toics TerraformOiCS) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (toics TerraformOiCS) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	if !generateDocs {
-		return
+		return nil
 	}
 
 	for _, example := range object.TestExamples() {
@@ -90,33 +110,42 @@ func (toics TerraformOiCS) GenerateResource(object api.Resource, templateData Te
 		targetFolder := path.Join(outputFolder, example.Name)
 
 		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-			log.Println(fmt.Errorf("error creating oics example directory %v: %v", targetFolder, err))
+			return fmt.Errorf("error creating oics example directory %v: %w", targetFolder, err)
 		}
 
 		oicsExampleTemplatePath := "templates/terraform/examples/base_configs/oics_example_file.tf.tmpl"
 		oicsExampleTemplates := []string{
 			oicsExampleTemplatePath,
 		}
-		templateData.GenerateFile(path.Join(targetFolder, "main.tf"), oicsExampleTemplatePath, example, false, oicsExampleTemplates...)
+		if err := templateData.GenerateFile(path.Join(targetFolder, "main.tf"), oicsExampleTemplatePath, example, false, oicsExampleTemplates...); err != nil {
+			return err
+		}
 
 		tutorialTemplatePath := "templates/terraform/examples/base_configs/tutorial.md.tmpl"
 		tutorialTemplates := []string{
 			tutorialTemplatePath,
 		}
-		templateData.GenerateFile(path.Join(targetFolder, "tutorial.md"), tutorialTemplatePath, example, false, tutorialTemplates...)
+		if err := templateData.GenerateFile(path.Join(targetFolder, "tutorial.md"), tutorialTemplatePath, example, false, tutorialTemplates...); err != nil {
+			return err
+		}
 
 		backingTemplatePath := "templates/terraform/examples/base_configs/example_backing_file.tf.tmpl"
 		backingTemplates := []string{
 			backingTemplatePath,
 		}
-		templateData.GenerateFile(path.Join(targetFolder, "backing_file.tf"), backingTemplatePath, example, false, backingTemplates...)
+		if err := templateData.GenerateFile(path.Join(targetFolder, "backing_file.tf"), backingTemplatePath, example, false, backingTemplates...); err != nil {
+			return err
+		}
 
 		motdTemplatePath := "templates/terraform/examples/static/motd.tmpl"
 		motdTemplates := []string{
 			motdTemplatePath,
 		}
-		templateData.GenerateFile(path.Join(targetFolder, "motd"), motdTemplatePath, example, false, motdTemplates...)
+		if err := templateData.GenerateFile(path.Join(targetFolder, "motd"), motdTemplatePath, example, false, motdTemplates...); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func (toics TerraformOiCS) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
@@ -49,6 +49,19 @@ type TerraformGoogleConversion struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Logger of the product being generated
+	logger *log.Logger
+}
+
+func init() {
+	Register(Registration{
+		Name:        "tgc",
+		Description: "Terraform to CAI conversion for terraform-google-conversion",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformGoogleConversion(product, versionName, startTime)
+		},
+	})
 }
 
 func NewTerraformGoogleConversion(product *api.Product, versionName string, startTime time.Time) TerraformGoogleConversion {
@@ -69,39 +82,46 @@ func NewTerraformGoogleConversion(product *api.Product, versionName string, star
 	return t
 }
 
-func (tgc TerraformGoogleConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
 	// Temporary shim to generate the missing resources directory. Can be removed
 	// once the folder exists downstream.
 	resourcesFolder := path.Join(outputFolder, "converters/google/resources")
 	if err := os.MkdirAll(resourcesFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourcesFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", resourcesFolder, err)
 	}
-	tgc.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	tgc.logger = loggerOrDefault(logger)
+	return tgc.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
 }
 
-func (tgc TerraformGoogleConversion) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	for _, object := range tgc.Product.Objects {
 		object.ExcludeIfNotInVersion(&tgc.Version)
 
 		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
+			tgc.logger.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
 
-		tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs)
+		if err := tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs); err != nil {
+			return fmt.Errorf("%s: %w", object.Name, err)
+		}
 	}
+	return nil
 }
 
-func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	if object.ExcludeTgc {
-		log.Printf("Skipping fine-grained resource %s", object.Name)
-		return
+		tgc.logger.Printf("Skipping fine-grained resource %s", object.Name)
+		return nil
 	}
 
 	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData.Logger = tgc.logger
 
 	if !object.IsExcluded() {
-		tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		if err := tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs); err != nil {
+			return err
+		}
 
 		if generateCode {
 			// tgc.GenerateResourceTests(object, *templateData, outputFolder)
@@ -111,36 +131,36 @@ func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputF
 
 	// if iam_policy is not defined or excluded, don't generate it
 	if object.IamPolicy == nil || object.IamPolicy.Exclude {
-		return
+		return nil
 	}
 
-	tgc.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
+	return tgc.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
 }
 
-func (tgc TerraformGoogleConversion) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "converters/google/resources/services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 
 	templatePath := "templates/tgc/resource_converter.go.tmpl"
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s.go", productName, google.Underscore(object.Name)))
-	templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
+	return templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
 }
 
 // Generate the IAM policy for this object. This is used to query and test
 // IAM policies separately from the resource itself
 // Docs are generated for the terraform provider, not here.
-func (tgc TerraformGoogleConversion) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	if !generateCode || object.IamPolicy.ExcludeTgc {
-		return
+		return nil
 	}
 
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "converters/google/resources/services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 
 	name := object.FilenameOverride
@@ -149,13 +169,18 @@ func (tgc TerraformGoogleConversion) GenerateIamPolicy(object api.Resource, temp
 	}
 
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s_iam.go", productName, name))
-	templateData.GenerateTGCIamResourceFile(targetFilePath, object)
+	if err := templateData.GenerateTGCIamResourceFile(targetFilePath, object); err != nil {
+		return err
+	}
 
 	targetFilePath = path.Join(targetFolder, fmt.Sprintf("iam_%s_%s.go", productName, name))
-	templateData.GenerateIamPolicyFile(targetFilePath, object)
+	if err := templateData.GenerateIamPolicyFile(targetFilePath, object); err != nil {
+		return err
+	}
 
 	// Don't generate tests - we can rely on the terraform provider
 	//  to test these.
+	return nil
 }
 
 // Generates the list of resources
@@ -232,7 +257,9 @@ func (tgc TerraformGoogleConversion) CompileFileList(outputFolder string, files
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...)
+		if err := fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...); err != nil {
+			log.Fatal(err)
+		}
 		tgc.replaceImportPath(outputFolder, target)
 	}
 }
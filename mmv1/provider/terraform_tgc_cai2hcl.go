@@ -35,6 +35,16 @@ type CaiToTerraformConversion struct {
 	StartTime time.Time
 }
 
+func init() {
+	Register(Registration{
+		Name:        "tgc_cai2hcl",
+		Description: "CAI to HCL conversion for terraform-google-conversion",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewCaiToTerraformConversion(product, versionName, startTime)
+		},
+	})
+}
+
 func NewCaiToTerraformConversion(product *api.Product, versionName string, startTime time.Time) CaiToTerraformConversion {
 	t := CaiToTerraformConversion{
 		Product:           product,
@@ -53,7 +63,8 @@ func NewCaiToTerraformConversion(product *api.Product, versionName string, start
 	return t
 }
 
-func (cai2hcl CaiToTerraformConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+func (cai2hcl CaiToTerraformConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	return nil
 }
 
 func (cai2hcl CaiToTerraformConversion) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
@@ -50,6 +50,9 @@ type TerraformGoogleConversionNext struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Logger of the product being generated
+	logger *log.Logger
 }
 
 type ResourceIdentifier struct {
@@ -59,6 +62,16 @@ type ResourceIdentifier struct {
 	AliasName     string // It can be "Default" or the same with ResourceName
 }
 
+func init() {
+	Register(Registration{
+		Name:        "tgc_next",
+		Description: "Bidirectional Terraform and CAI conversion for terraform-google-conversion",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformGoogleConversionNext(product, versionName, startTime)
+		},
+	})
+}
+
 func NewTerraformGoogleConversionNext(product *api.Product, versionName string, startTime time.Time) TerraformGoogleConversionNext {
 	t := TerraformGoogleConversionNext{
 		Product:                    product,
@@ -78,55 +91,65 @@ func NewTerraformGoogleConversionNext(product *api.Product, versionName string,
 	return t
 }
 
-func (tgc TerraformGoogleConversionNext) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool, logger *log.Logger) error {
+	tgc.logger = loggerOrDefault(logger)
 	for _, object := range tgc.Product.Objects {
 		object.ExcludeIfNotInVersion(&tgc.Version)
 
 		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
+			tgc.logger.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
 
-		tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs)
+		if err := tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs); err != nil {
+			return fmt.Errorf("%s: %w", object.Name, err)
+		}
 	}
+	return nil
 }
 
-func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) error {
 	if !object.IncludeInTGCNext {
-		return
+		return nil
 	}
 
 	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData.Logger = tgc.logger
 
 	if !object.IsExcluded() {
-		tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
-		tgc.GenerateResourceTests(object, *templateData, outputFolder)
+		if err := tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs); err != nil {
+			return err
+		}
+		return tgc.GenerateResourceTests(object, *templateData, outputFolder)
 	}
+	return nil
 }
 
-func (tgc TerraformGoogleConversionNext) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) error {
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "pkg/services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 
 	converters := []string{"tfplan2cai", "cai2hcl"}
 	for _, converter := range converters {
 		templatePath := fmt.Sprintf("templates/tgc_next/%s/resource_converter.go.tmpl", converter)
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s_%s.go", productName, google.Underscore(object.Name), converter))
-		templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
+		if err := templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object); err != nil {
+			return err
+		}
 	}
 
 	templatePath := "templates/tgc_next/services/resource.go.tmpl"
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s.go", productName, google.Underscore(object.Name)))
-	templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
+	return templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
 }
 
 func (tgc TerraformGoogleConversionNext) GenerateCaiToHclObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
 }
 
-func (tgc *TerraformGoogleConversionNext) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
+func (tgc *TerraformGoogleConversionNext) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) error {
 	eligibleExample := false
 	for _, example := range object.Examples {
 		if !example.ExcludeTest {
@@ -137,16 +160,16 @@ func (tgc *TerraformGoogleConversionNext) GenerateResourceTests(object api.Resou
 		}
 	}
 	if !eligibleExample {
-		return
+		return nil
 	}
 
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "test", "services", productName)
 	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
-		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		return fmt.Errorf("error creating parent directory %v: %w", targetFolder, err)
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s_generated_test.go", productName, google.Underscore(object.Name)))
-	templateData.GenerateTGCNextTestFile(targetFilePath, object)
+	return templateData.GenerateTGCNextTestFile(targetFilePath, object)
 }
 
 func (tgc TerraformGoogleConversionNext) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
@@ -200,7 +223,9 @@ func (tgc TerraformGoogleConversionNext) CompileFileList(outputFolder string, fi
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
+		if err := fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...); err != nil {
+			log.Fatal(err)
+		}
 		tgc.replaceImportPath(outputFolder, target)
 	}
 }
@@ -45,6 +45,9 @@ type GoogleProviderConfigPluginFrameworkModel struct {
 	Zone                               types.String `tfsdk:"zone"`
 	Scopes                             types.List   `tfsdk:"scopes"`
 	//	omit Batching
+	//	omit Retry
+	//	omit RateLimit
+	//	omit ReadCache
 	UserProjectOverride                       types.Bool   `tfsdk:"user_project_override"`
 	RequestTimeout                            types.String `tfsdk:"request_timeout"`
 	RequestReason                             types.String `tfsdk:"request_reason"`
@@ -0,0 +1,163 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// EphemeralArguments holds the configured arguments of a generated ephemeral
+// resource. It implements tpgresource.TerraformResourceData so that URLs can
+// be built with tpgresource.ReplaceVars, including the fallback to the
+// provider's project, region and zone.
+type EphemeralArguments map[string]interface{}
+
+var _ tpgresource.TerraformResourceData = EphemeralArguments{}
+
+func (a EphemeralArguments) HasChange(string) bool {
+	return false
+}
+
+func (a EphemeralArguments) GetOkExists(key string) (interface{}, bool) {
+	v, ok := a[key]
+	return v, ok
+}
+
+func (a EphemeralArguments) GetOk(key string) (interface{}, bool) {
+	v, ok := a[key]
+	if !ok || v == nil || v == "" {
+		return v, false
+	}
+	return v, true
+}
+
+func (a EphemeralArguments) Get(key string) interface{} {
+	return a[key]
+}
+
+func (a EphemeralArguments) Set(key string, v interface{}) error {
+	a[key] = v
+	return nil
+}
+
+func (a EphemeralArguments) SetId(string) {}
+
+func (a EphemeralArguments) Id() string {
+	return ""
+}
+
+func (a EphemeralArguments) GetProviderMeta(interface{}) error {
+	return nil
+}
+
+func (a EphemeralArguments) Timeout(string) time.Duration {
+	return 0
+}
+
+// Builds the URL of an ephemeral resource's method from its arguments.
+func EphemeralUrl(config *transport_tpg.Config, args EphemeralArguments, linkTmpl string) (string, error) {
+	return tpgresource.ReplaceVars(args, config, linkTmpl)
+}
+
+// Returns the value at a dot separated path in an API response, or nil if
+// any part of the path is missing.
+func ResponseValue(res map[string]interface{}, path string) interface{} {
+	var v interface{} = res
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func StringResponseValue(v interface{}) types.String {
+	if v == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(fmt.Sprintf("%v", v))
+}
+
+// Converts an integer from an API response, where int64 values are encoded
+// as strings.
+func Int64ResponseValue(v interface{}, diags *diag.Diagnostics) types.Int64 {
+	switch i := v.(type) {
+	case nil:
+		return types.Int64Null()
+	case float64:
+		return types.Int64Value(int64(i))
+	case string:
+		n, err := strconv.ParseInt(i, 10, 64)
+		if err != nil {
+			diags.AddError("Unexpected integer in response", err.Error())
+			return types.Int64Null()
+		}
+		return types.Int64Value(n)
+	}
+	diags.AddError("Unexpected integer in response", fmt.Sprintf("got %T", v))
+	return types.Int64Null()
+}
+
+func Float64ResponseValue(v interface{}, diags *diag.Diagnostics) types.Float64 {
+	switch f := v.(type) {
+	case nil:
+		return types.Float64Null()
+	case float64:
+		return types.Float64Value(f)
+	case string:
+		n, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			diags.AddError("Unexpected number in response", err.Error())
+			return types.Float64Null()
+		}
+		return types.Float64Value(n)
+	}
+	diags.AddError("Unexpected number in response", fmt.Sprintf("got %T", v))
+	return types.Float64Null()
+}
+
+func BoolResponseValue(v interface{}) types.Bool {
+	b, ok := v.(bool)
+	if !ok {
+		return types.BoolNull()
+	}
+	return types.BoolValue(b)
+}
+
+func StringListResponseValue(ctx context.Context, v interface{}, diags *diag.Diagnostics) types.List {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return types.ListNull(types.StringType)
+	}
+	items := make([]string, 0, len(raw))
+	for _, item := range raw {
+		items = append(items, fmt.Sprintf("%v", item))
+	}
+	l, d := types.ListValueFrom(ctx, types.StringType, items)
+	diags.Append(d...)
+	return l
+}
+
+func StringMapResponseValue(ctx context.Context, v interface{}, diags *diag.Diagnostics) types.Map {
+	raw, ok := v.(map[string]interface{})
+	if !ok {
+		return types.MapNull(types.StringType)
+	}
+	items := make(map[string]string, len(raw))
+	for k, item := range raw {
+		items[k] = fmt.Sprintf("%v", item)
+	}
+	m, d := types.MapValueFrom(ctx, types.StringType, items)
+	diags.Append(d...)
+	return m
+}
@@ -0,0 +1,174 @@
+package fwresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// attributeGetter is implemented by tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}
+
+// Returns the map of strings held by the attribute name, or an empty map if
+// it is null or unknown.
+func getStringMap(ctx context.Context, g attributeGetter, name string, diags *diag.Diagnostics) map[string]string {
+	var v types.Map
+	diags.Append(g.GetAttribute(ctx, path.Root(name), &v)...)
+	m := make(map[string]string)
+	if !IsKnown(v) {
+		return m
+	}
+	diags.Append(v.ElementsAs(ctx, &m, false)...)
+	return m
+}
+
+// Sets the planned value of a computed map of strings attribute.
+func setStringMap(ctx context.Context, resp *resource.ModifyPlanResponse, name string, m map[string]string) {
+	v, d := types.MapValueFrom(ctx, types.StringType, m)
+	resp.Diagnostics.Append(d...)
+	if d.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), v)...)
+}
+
+// Returns the effective values of labels or annotations: those of the
+// current state, updated with the values managed by Terraform and without
+// the keys that Terraform no longer manages.
+func effectiveStringMap(effective, oldManaged, newManaged map[string]string) map[string]string {
+	m := make(map[string]string, len(effective))
+	for k, v := range effective {
+		m[k] = v
+	}
+	for k, v := range newManaged {
+		m[k] = v
+	}
+	for k := range oldManaged {
+		if _, ok := newManaged[k]; !ok {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
+// SetLabelsPlan plans the terraform_labels and effective_labels attributes
+// of a resource with a root labels attribute, like tpgresource.SetLabelsDiff
+// does for SDKv2 resources. terraform_labels merges the provider default
+// labels, the attribution label and the labels of the resource, and
+// effective_labels holds all of the labels that are sent to the API.
+func SetLabelsPlan(ctx context.Context, config *transport_tpg.Config, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, skipAttribution bool) {
+	// The resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If "labels" is unknown, so are "terraform_labels" and "effective_labels".
+	if labels.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("terraform_labels"), types.MapUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+	oldTerraformLabels := make(map[string]string)
+	effectiveLabels := make(map[string]string)
+	if !creating {
+		oldTerraformLabels = getStringMap(ctx, req.State, "terraform_labels", &resp.Diagnostics)
+		effectiveLabels = getStringMap(ctx, req.State, "effective_labels", &resp.Diagnostics)
+	}
+
+	// Merge provider default labels with the user defined labels in the resource to get terraform managed labels
+	terraformLabels := make(map[string]string)
+	if config != nil {
+		for k, v := range config.DefaultLabels {
+			terraformLabels[k] = v
+		}
+
+		// Append optional label indicating the resource was provisioned using Terraform
+		if !skipAttribution && config.AddTerraformAttributionLabel {
+			_, hasExistingLabel := effectiveLabels[transport_tpg.AttributionKey]
+			if hasExistingLabel ||
+				config.TerraformAttributionLabelAdditionStrategy == transport_tpg.ProactiveAttributionStrategy ||
+				(config.TerraformAttributionLabelAdditionStrategy == transport_tpg.CreateOnlyAttributionStrategy && creating) {
+				terraformLabels[transport_tpg.AttributionKey] = transport_tpg.AttributionValue
+			}
+		}
+	}
+
+	for k, v := range getStringMap(ctx, req.Plan, "labels", &resp.Diagnostics) {
+		terraformLabels[k] = v
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStringMap(ctx, resp, "terraform_labels", terraformLabels)
+	setStringMap(ctx, resp, "effective_labels", effectiveStringMap(effectiveLabels, oldTerraformLabels, terraformLabels))
+}
+
+// SetAnnotationsPlan plans the effective_annotations attribute of a resource
+// with a root annotations attribute, like tpgresource.SetAnnotationsDiff does
+// for SDKv2 resources.
+func SetAnnotationsPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var annotations types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("annotations"), &annotations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If "annotations" is unknown, so is "effective_annotations".
+	if annotations.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_annotations"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	oldAnnotations := make(map[string]string)
+	effectiveAnnotations := make(map[string]string)
+	if !req.State.Raw.IsNull() {
+		oldAnnotations = getStringMap(ctx, req.State, "annotations", &resp.Diagnostics)
+		effectiveAnnotations = getStringMap(ctx, req.State, "effective_annotations", &resp.Diagnostics)
+	}
+	newAnnotations := getStringMap(ctx, req.Plan, "annotations", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStringMap(ctx, resp, "effective_annotations", effectiveStringMap(effectiveAnnotations, oldAnnotations, newAnnotations))
+}
+
+// Converts labels or annotations from an API response, keeping only the keys
+// of the prior value, which are those managed by Terraform. Like
+// tpgresource.SetLabels, other keys are only kept in the effective value.
+func ConfiguredStringMapResponseValue(ctx context.Context, v interface{}, prior types.Map, diags *diag.Diagnostics) types.Map {
+	if !IsKnown(prior) {
+		return prior
+	}
+	raw, _ := v.(map[string]interface{})
+	items := make(map[string]string, len(prior.Elements()))
+	for k := range prior.Elements() {
+		if item, ok := raw[k]; ok {
+			items[k] = StringResponseValue(item).ValueString()
+		}
+	}
+	m, d := types.MapValueFrom(ctx, types.StringType, items)
+	diags.Append(d...)
+	return m
+}
@@ -0,0 +1,91 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// SDKv2ListResource implements the parts of a plugin-framework list resource
+// that are shared by generated list resources of SDKv2 resources: the
+// resource and identity schemas are taken from the SDKv2 resource, and
+// results are built from its ResourceData.
+type SDKv2ListResource struct {
+	Resource       *schema.Resource
+	IdentityFields []string
+
+	ProviderConfig *transport_tpg.Config
+}
+
+func (l *SDKv2ListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*transport_tpg.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *transport_tpg.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	l.ProviderConfig = config
+}
+
+func (l *SDKv2ListResource) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	resp.ProtoV5Schema = l.Resource.ProtoSchema(ctx)()
+	resp.ProtoV5IdentitySchema = l.Resource.ProtoIdentitySchema(ctx)()
+}
+
+// Sets the identity of a list result, and its resource if includeResource is
+// set, from the fields of d.
+func (l *SDKv2ListResource) SetResult(d *schema.ResourceData, includeResource bool, result *list.ListResult) {
+	identity, err := d.Identity()
+	if err != nil {
+		result.Diagnostics.AddError("Error reading resource identity", err.Error())
+		return
+	}
+	for _, field := range l.IdentityFields {
+		if err := identity.Set(field, d.Get(field)); err != nil {
+			result.Diagnostics.AddError(fmt.Sprintf("Error setting %s in resource identity", field), err.Error())
+			return
+		}
+	}
+
+	identityValue, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics.AddError("Error converting resource identity", err.Error())
+		return
+	}
+	result.Identity.Raw = *identityValue
+
+	if includeResource {
+		resourceValue, err := d.TfTypeResourceState()
+		if err != nil {
+			result.Diagnostics.AddError("Error converting resource", err.Error())
+			return
+		}
+		result.Resource.Raw = *resourceValue
+	}
+}
+
+// Returns the name of a listed object relative to the API, e.g.
+// projects/p/locations/l/instances/i, from its selfLink if it has one and
+// its name otherwise.
+func ListItemName(item map[string]interface{}) string {
+	if v, ok := item["selfLink"].(string); ok {
+		if i := strings.Index(v, "projects/"); i >= 0 {
+			return v[i:]
+		}
+	}
+	name, _ := item["name"].(string)
+	return name
+}
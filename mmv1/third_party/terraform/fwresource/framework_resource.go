@@ -0,0 +1,156 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+)
+
+// ResourceArguments holds the attributes of a generated plugin-framework
+// resource that are used to build its URLs, and its id, which is parsed on
+// import. Like EphemeralArguments, it implements
+// tpgresource.TerraformResourceData.
+type ResourceArguments struct {
+	EphemeralArguments
+	id string
+}
+
+var _ tpgresource.TerraformResourceData = &ResourceArguments{}
+
+func NewResourceArguments(id string) *ResourceArguments {
+	return &ResourceArguments{
+		EphemeralArguments: EphemeralArguments{},
+		id:                 id,
+	}
+}
+
+func (a *ResourceArguments) SetId(id string) {
+	a.id = id
+}
+
+func (a *ResourceArguments) Id() string {
+	return a.id
+}
+
+// Returns whether a value is neither null nor unknown, and so is sent to the
+// API.
+func IsKnown(v attr.Value) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+// Converts a primitive, a list of strings or a map of strings to its API
+// representation.
+func ExpandValue(ctx context.Context, v attr.Value, diags *diag.Diagnostics) interface{} {
+	switch v := v.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.Bool:
+		return v.ValueBool()
+	case types.List:
+		items := make([]interface{}, 0, len(v.Elements()))
+		for _, item := range v.Elements() {
+			items = append(items, ExpandValue(ctx, item, diags))
+		}
+		return items
+	case types.Map:
+		items := make(map[string]interface{}, len(v.Elements()))
+		for k, item := range v.Elements() {
+			items[k] = ExpandValue(ctx, item, diags)
+		}
+		return items
+	}
+	diags.AddError("Unexpected value type", fmt.Sprintf("got %T", v))
+	return nil
+}
+
+// Converts a nested object to its API representation with the generated
+// expand function of its model.
+func ExpandObject[T any](ctx context.Context, v types.Object, expand func(context.Context, T, *diag.Diagnostics) map[string]interface{}, diags *diag.Diagnostics) map[string]interface{} {
+	var m T
+	d := v.As(ctx, &m, basetypes.ObjectAsOptions{})
+	diags.Append(d...)
+	if d.HasError() {
+		return nil
+	}
+	return expand(ctx, m, diags)
+}
+
+// Converts a list of nested objects to its API representation with the
+// generated expand function of their model.
+func ExpandObjectList[T any](ctx context.Context, v types.List, expand func(context.Context, T, *diag.Diagnostics) map[string]interface{}, diags *diag.Diagnostics) []interface{} {
+	var ms []T
+	d := v.ElementsAs(ctx, &ms, false)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil
+	}
+	items := make([]interface{}, 0, len(ms))
+	for _, m := range ms {
+		items = append(items, expand(ctx, m, diags))
+	}
+	return items
+}
+
+// Converts a nested object from an API response with the generated flatten
+// function of its model.
+func FlattenObject[T any](ctx context.Context, v interface{}, attrTypes map[string]attr.Type, flatten func(context.Context, map[string]interface{}, *diag.Diagnostics) T, diags *diag.Diagnostics) types.Object {
+	res, ok := v.(map[string]interface{})
+	if !ok {
+		return types.ObjectNull(attrTypes)
+	}
+	o, d := types.ObjectValueFrom(ctx, attrTypes, flatten(ctx, res, diags))
+	diags.Append(d...)
+	return o
+}
+
+// Converts a list of nested objects from an API response with the generated
+// flatten function of their model.
+func FlattenObjectList[T any](ctx context.Context, v interface{}, attrTypes map[string]attr.Type, flatten func(context.Context, map[string]interface{}, *diag.Diagnostics) T, diags *diag.Diagnostics) types.List {
+	elemType := types.ObjectType{AttrTypes: attrTypes}
+	raw, ok := v.([]interface{})
+	if !ok {
+		return types.ListNull(elemType)
+	}
+	items := make([]T, 0, len(raw))
+	for _, item := range raw {
+		res, _ := item.(map[string]interface{})
+		items = append(items, flatten(ctx, res, diags))
+	}
+	l, d := types.ListValueFrom(ctx, elemType, items)
+	diags.Append(d...)
+	return l
+}
+
+// Converts a list of primitives from an API response to a list of elemType.
+func ListResponseValue(ctx context.Context, v interface{}, elemType attr.Type, diags *diag.Diagnostics) types.List {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return types.ListNull(elemType)
+	}
+	items := make([]attr.Value, 0, len(raw))
+	for _, item := range raw {
+		switch {
+		case elemType.Equal(types.Int64Type):
+			items = append(items, Int64ResponseValue(item, diags))
+		case elemType.Equal(types.Float64Type):
+			items = append(items, Float64ResponseValue(item, diags))
+		case elemType.Equal(types.BoolType):
+			items = append(items, BoolResponseValue(item))
+		default:
+			items = append(items, StringResponseValue(item))
+		}
+	}
+	l, d := types.ListValue(elemType, items)
+	diags.Append(d...)
+	return l
+}
@@ -1,6 +1,7 @@
 package appengine
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -24,6 +25,10 @@ type AppEngineOperationWaiter struct {
 }
 
 func (w *AppEngineOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *AppEngineOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -31,10 +36,14 @@ func (w *AppEngineOperationWaiter) QueryOp() (interface{}, error) {
 	if len(matches) != 2 {
 		return nil, fmt.Errorf("Expected %d results of parsing operation name, got %d from %s", 2, len(matches), w.Op.Name)
 	}
-	return w.Service.Apps.Operations.Get(w.AppId, matches[1]).Do()
+	return w.Service.Apps.Operations.Get(w.AppId, matches[1]).Context(ctx).Do()
 }
 
 func AppEngineOperationWaitTimeWithResponse(config *transport_tpg.Config, res interface{}, response *map[string]interface{}, appId, activity, userAgent string, timeout time.Duration) error {
+	return AppEngineOperationWaitTimeWithResponseContext(context.Background(), config, res, response, appId, activity, userAgent, timeout)
+}
+
+func AppEngineOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, res interface{}, response *map[string]interface{}, appId, activity, userAgent string, timeout time.Duration) error {
 	op := &appengine.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -49,13 +58,17 @@ func AppEngineOperationWaitTimeWithResponse(config *transport_tpg.Config, res in
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func AppEngineOperationWaitTime(config *transport_tpg.Config, res interface{}, appId, activity, userAgent string, timeout time.Duration) error {
+	return AppEngineOperationWaitTimeContext(context.Background(), config, res, appId, activity, userAgent, timeout)
+}
+
+func AppEngineOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, res interface{}, appId, activity, userAgent string, timeout time.Duration) error {
 	op := &appengine.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -70,5 +83,5 @@ func AppEngineOperationWaitTime(config *transport_tpg.Config, res interface{}, a
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -1,6 +1,7 @@
 package chronicle
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -17,6 +18,10 @@ type ChronicleOperationWaiter struct {
 }
 
 func (w *ChronicleOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *ChronicleOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -27,6 +32,7 @@ func (w *ChronicleOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-chronicle.googleapis.com/v1/%s", region, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -48,17 +54,25 @@ func createChronicleWaiter(config *transport_tpg.Config, op map[string]interface
 }
 
 func ChronicleOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ChronicleOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+func ChronicleOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createChronicleWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func ChronicleOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ChronicleOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func ChronicleOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -68,5 +82,5 @@ func ChronicleOperationWaitTime(config *transport_tpg.Config, op map[string]inte
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
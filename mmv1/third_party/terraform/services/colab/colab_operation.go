@@ -1,6 +1,7 @@
 package colab
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +19,10 @@ type ColabOperationWaiter struct {
 }
 
 func (w *ColabOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *ColabOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -28,6 +33,7 @@ func (w *ColabOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-aiplatform.googleapis.com/v1/%s", region, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -50,11 +56,16 @@ func createColabWaiter(config *transport_tpg.Config, op map[string]interface{},
 
 // nolint: deadcode,unused
 func ColabOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ColabOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func ColabOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createColabWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -65,6 +76,10 @@ func ColabOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[str
 }
 
 func ColabOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ColabOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func ColabOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -74,5 +89,5 @@ func ColabOperationWaitTime(config *transport_tpg.Config, op map[string]interfac
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -43,7 +43,7 @@ func dataSourceComputeRegionBackendServiceRead(d *schema.ResourceData, meta inte
 	id := fmt.Sprintf("projects/%s/regions/%s/backendServices/%s", project, region, name)
 	d.SetId(id)
 
-	err = ResourceComputeRegionBackendService().Read(d, meta)
+	err = resourceComputeRegionBackendServiceRead(d, meta)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package containerattached
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -17,6 +18,10 @@ type ContainerAttachedOperationWaiter struct {
 }
 
 func (w *ContainerAttachedOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *ContainerAttachedOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -27,6 +32,7 @@ func (w *ContainerAttachedOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-gkemulticloud.googleapis.com/v1/%s", region, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -49,17 +55,26 @@ func createContainerAttachedWaiter(config *transport_tpg.Config, op map[string]i
 
 // nolint: deadcode,unused
 func ContainerAttachedOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ContainerAttachedOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func ContainerAttachedOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createContainerAttachedWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func ContainerAttachedOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ContainerAttachedOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func ContainerAttachedOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -69,5 +84,5 @@ func ContainerAttachedOperationWaitTime(config *transport_tpg.Config, op map[str
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
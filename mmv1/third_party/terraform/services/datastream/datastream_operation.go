@@ -2,6 +2,7 @@ package datastream
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -21,6 +22,10 @@ type DatastreamOperationWaiter struct {
 }
 
 func (w *DatastreamOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *DatastreamOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -28,6 +33,7 @@ func (w *DatastreamOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("%s%s", w.Config.DatastreamBasePath, w.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -65,17 +71,26 @@ func createDatastreamWaiter(config *transport_tpg.Config, op map[string]interfac
 
 // nolint: deadcode,unused
 func DatastreamOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DatastreamOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func DatastreamOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createDatastreamWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.Op.Response), response)
 }
 
 func DatastreamOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DatastreamOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func DatastreamOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -85,7 +100,7 @@ func DatastreamOperationWaitTime(config *transport_tpg.Config, op map[string]int
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
 
 // DatastreamOperationError wraps datastream.Status and implements the
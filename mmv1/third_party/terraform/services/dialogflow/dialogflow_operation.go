@@ -1,6 +1,7 @@
 package dialogflow
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,10 @@ type DialogflowOperationWaiter struct {
 }
 
 func (w *DialogflowOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *DialogflowOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -37,6 +42,7 @@ func (w *DialogflowOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-dialogflow.googleapis.com/v2/%s", location, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -59,11 +65,16 @@ func createDialogflowWaiter(config *transport_tpg.Config, op map[string]interfac
 
 // nolint: deadcode,unused
 func DialogflowOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DialogflowOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func DialogflowOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createDialogflowWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -74,6 +85,10 @@ func DialogflowOperationWaitTimeWithResponse(config *transport_tpg.Config, op ma
 }
 
 func DialogflowOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DialogflowOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func DialogflowOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -83,5 +98,5 @@ func DialogflowOperationWaitTime(config *transport_tpg.Config, op map[string]int
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -1,6 +1,7 @@
 package discoveryengine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,10 @@ type DiscoveryEngineOperationWaiter struct {
 }
 
 func (w *DiscoveryEngineOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *DiscoveryEngineOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("cannot query operation, it's unset or nil")
 	}
@@ -38,6 +43,7 @@ func (w *DiscoveryEngineOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("%s%s", basePath, opName)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -60,11 +66,16 @@ func createDiscoveryEngineWaiter(config *transport_tpg.Config, op map[string]int
 
 // nolint: deadcode,unused
 func DiscoveryEngineOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DiscoveryEngineOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func DiscoveryEngineOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createDiscoveryEngineWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -75,6 +86,10 @@ func DiscoveryEngineOperationWaitTimeWithResponse(config *transport_tpg.Config,
 }
 
 func DiscoveryEngineOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return DiscoveryEngineOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+func DiscoveryEngineOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -84,5 +99,5 @@ func DiscoveryEngineOperationWaitTime(config *transport_tpg.Config, op map[strin
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
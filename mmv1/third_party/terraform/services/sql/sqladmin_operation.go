@@ -2,6 +2,7 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -57,6 +58,10 @@ func (w *SqlAdminOperationWaiter) SetOp(op interface{}) error {
 }
 
 func (w *SqlAdminOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *SqlAdminOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, waiter is unset or nil.")
 	}
@@ -72,8 +77,9 @@ func (w *SqlAdminOperationWaiter) QueryOp() (interface{}, error) {
 	var op interface{}
 	var err error
 	err = transport_tpg.Retry(transport_tpg.RetryOptions{
+		Context: ctx,
 		RetryFunc: func() error {
-			op, err = w.Service.Operations.Get(w.Project, w.Op.Name).Do()
+			op, err = w.Service.Operations.Get(w.Project, w.Op.Name).Context(ctx).Do()
 			return err
 		},
 		Timeout: transport_tpg.DefaultRequestTimeout,
@@ -103,6 +109,10 @@ func (w *SqlAdminOperationWaiter) TargetStates() []string {
 }
 
 func SqlAdminOperationWaitTime(config *transport_tpg.Config, res interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return SqlAdminOperationWaitTimeContext(context.Background(), config, res, project, activity, userAgent, timeout)
+}
+
+func SqlAdminOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, res interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	op := &sqladmin.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -117,7 +127,7 @@ func SqlAdminOperationWaitTime(config *transport_tpg.Config, res interface{}, pr
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
 
 // SqlAdminOperationError wraps sqladmin.OperationError and implements the
@@ -3,6 +3,7 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,10 @@ type StorageOperationWaiter struct {
 }
 
 func (w *StorageOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *StorageOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -37,6 +42,7 @@ func (w *StorageOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf(w.SelfLink)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   ctx,
 		Config:    w.Config,
 		Method:    "GET",
 		RawURL:    url,
@@ -62,11 +68,16 @@ func createStorageWaiter(config *transport_tpg.Config, op map[string]interface{}
 
 // nolint: deadcode,unused
 func StorageOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return StorageOperationWaitTimeWithResponseContext(context.Background(), config, op, response, activity, userAgent, timeout)
+}
+
+// nolint: deadcode,unused
+func StorageOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	w, err := createStorageWaiter(config, op, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -77,6 +88,10 @@ func StorageOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[s
 }
 
 func StorageOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return StorageOperationWaitTimeContext(context.Background(), config, op, activity, userAgent, timeout)
+}
+
+func StorageOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -86,5 +101,5 @@ func StorageOperationWaitTime(config *transport_tpg.Config, op map[string]interf
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -18,6 +19,10 @@ type TagsLocationOperationWaiter struct {
 }
 
 func (w *TagsLocationOperationWaiter) QueryOp() (interface{}, error) {
+	return w.QueryOpContext(context.Background())
+}
+
+func (w *TagsLocationOperationWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
 	if w == nil {
 		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
 	}
@@ -26,6 +31,7 @@ func (w *TagsLocationOperationWaiter) QueryOp() (interface{}, error) {
 		// Found location in Op.Name, fill it in TagsLocationBasePath and rewrite URL
 		url := fmt.Sprintf("%s%s", strings.Replace(w.Config.TagsLocationBasePath, "{{location}}", location, 1), w.CommonOperationWaiter.Op.Name)
 		return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+			Context:   ctx,
 			Config:    w.Config,
 			Method:    "GET",
 			RawURL:    url,
@@ -34,6 +40,7 @@ func (w *TagsLocationOperationWaiter) QueryOp() (interface{}, error) {
 	} else {
 		url := fmt.Sprintf("%s%s", w.Config.TagsBasePath, w.CommonOperationWaiter.Op.Name)
 		return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+			Context:   ctx,
 			Config:    w.Config,
 			Method:    "GET",
 			RawURL:    url,
@@ -54,17 +61,25 @@ func createTagsLocationWaiter(config *transport_tpg.Config, op map[string]interf
 }
 
 func TagsLocationOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return TagsLocationOperationWaitTimeWithResponseContext(context.Background(), config, op, response, activity, userAgent, timeout)
+}
+
+func TagsLocationOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	w, err := createTagsLocationWaiter(config, op, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func TagsLocationOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return TagsLocationOperationWaitTimeContext(context.Background(), config, op, activity, userAgent, timeout)
+}
+
+func TagsLocationOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -74,7 +89,7 @@ func TagsLocationOperationWaitTime(config *transport_tpg.Config, op map[string]i
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
 
 func GetLocationFromOpName(opName string) string {
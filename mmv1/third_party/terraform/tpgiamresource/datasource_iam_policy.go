@@ -3,6 +3,7 @@ package tpgiamresource
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
@@ -27,7 +28,7 @@ func DataSourceIamPolicy(parentSpecificSchema map[string]*schema.Schema, newUpda
 	}
 
 	return &schema.Resource{
-		Read: DatasourceIamPolicyRead(newUpdaterFunc),
+		ReadContext: tpgresource.ContextCRUDFunc(DatasourceIamPolicyRead(newUpdaterFunc)),
 		// if non-empty, this will be used to send a deprecation message when the
 		// datasource is used.
 		DeprecationMessage: settings.DeprecationMessage,
@@ -36,8 +37,8 @@ func DataSourceIamPolicy(parentSpecificSchema map[string]*schema.Schema, newUpda
 	}
 }
 
-func DatasourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func DatasourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -45,7 +46,7 @@ func DatasourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Re
 			return err
 		}
 
-		policy, err := iamPolicyReadWithRetry(updater)
+		policy, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %q with IAM Policy", updater.DescribeResource()))
 		}
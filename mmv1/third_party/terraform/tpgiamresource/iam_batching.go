@@ -1,6 +1,7 @@
 package tpgiamresource
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -47,7 +48,9 @@ func sendBatchModifyIamPolicy(updater ResourceIamUpdater) transport_tpg.BatcherS
 		if !ok {
 			return nil, fmt.Errorf("provider error: expected data to be type []iamPolicyModifyFunc, got %v with type %T", body, body)
 		}
-		return nil, iamPolicyReadModifyWrite(updater, func(policy *cloudresourcemanager.Policy) error {
+		// The batch combines the changes of several resources, so it is not
+		// cancelled with any one of them
+		return nil, iamPolicyReadModifyWrite(context.Background(), updater, func(policy *cloudresourcemanager.Policy) error {
 			for _, modifyF := range modifiers {
 				if err := modifyF(policy); err != nil {
 					return err
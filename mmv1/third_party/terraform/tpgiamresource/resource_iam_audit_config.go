@@ -1,6 +1,7 @@
 package tpgiamresource
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -50,11 +51,11 @@ func ResourceIamAuditConfig(parentSpecificSchema map[string]*schema.Schema, newU
 	settings := NewIamSettings(options...)
 
 	return &schema.Resource{
-		Create: resourceIamAuditConfigCreateUpdate(newUpdaterFunc, settings.EnableBatching),
-		Read:   resourceIamAuditConfigRead(newUpdaterFunc),
-		Update: resourceIamAuditConfigCreateUpdate(newUpdaterFunc, settings.EnableBatching),
-		Delete: resourceIamAuditConfigDelete(newUpdaterFunc, settings.EnableBatching),
-		Schema: tpgresource.MergeSchemas(iamAuditConfigSchema, parentSpecificSchema),
+		CreateContext: tpgresource.ContextCRUDFunc(resourceIamAuditConfigCreateUpdate(newUpdaterFunc, settings.EnableBatching)),
+		ReadContext:   tpgresource.ContextCRUDFunc(resourceIamAuditConfigRead(newUpdaterFunc)),
+		UpdateContext: tpgresource.ContextCRUDFunc(resourceIamAuditConfigCreateUpdate(newUpdaterFunc, settings.EnableBatching)),
+		DeleteContext: tpgresource.ContextCRUDFunc(resourceIamAuditConfigDelete(newUpdaterFunc, settings.EnableBatching)),
+		Schema:        tpgresource.MergeSchemas(iamAuditConfigSchema, parentSpecificSchema),
 		Importer: &schema.ResourceImporter{
 			State: iamAuditConfigImport(resourceIdParser),
 		},
@@ -62,8 +63,8 @@ func ResourceIamAuditConfig(parentSpecificSchema map[string]*schema.Schema, newU
 	}
 }
 
-func resourceIamAuditConfigRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamAuditConfigRead(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 		updater, err := newUpdaterFunc(d, config)
 		if err != nil {
@@ -71,7 +72,7 @@ func resourceIamAuditConfigRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema
 		}
 
 		eAuditConfig := getResourceIamAuditConfig(d)
-		p, err := iamPolicyReadWithRetry(updater)
+		p, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("AuditConfig for %s on %q", eAuditConfig.Service, updater.DescribeResource()))
 		}
@@ -135,8 +136,8 @@ func iamAuditConfigImport(resourceIdParser ResourceIdParserFunc) schema.StateFun
 	}
 }
 
-func resourceIamAuditConfigCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(*schema.ResourceData, interface{}) error {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamAuditConfigCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -154,18 +155,18 @@ func resourceIamAuditConfigCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config, fmt.Sprintf(
 				"Overwrite audit config for service %s on resource %q", ac.Service, updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return err
 		}
 		d.SetId(updater.GetResourceId() + "/audit_config/" + ac.Service)
-		return resourceIamAuditConfigRead(newUpdaterFunc)(d, meta)
+		return resourceIamAuditConfigRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
-func resourceIamAuditConfigDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) schema.DeleteFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamAuditConfigDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -182,13 +183,13 @@ func resourceIamAuditConfigDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enab
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config, fmt.Sprintf(
 				"Delete audit config for service %s on resource %q", ac.Service, updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %s with IAM audit config %q", updater.DescribeResource(), d.Id()))
 		}
 
-		return resourceIamAuditConfigRead(newUpdaterFunc)(d, meta)
+		return resourceIamAuditConfigRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
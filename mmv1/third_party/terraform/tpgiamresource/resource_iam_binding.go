@@ -1,6 +1,7 @@
 package tpgiamresource
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -67,10 +68,10 @@ func ResourceIamBinding(parentSpecificSchema map[string]*schema.Schema, newUpdat
 	settings := NewIamSettings(options...)
 
 	return &schema.Resource{
-		Create: resourceIamBindingCreateUpdate(newUpdaterFunc, settings.EnableBatching),
-		Read:   resourceIamBindingRead(newUpdaterFunc),
-		Update: resourceIamBindingCreateUpdate(newUpdaterFunc, settings.EnableBatching),
-		Delete: resourceIamBindingDelete(newUpdaterFunc, settings.EnableBatching),
+		CreateContext: tpgresource.ContextCRUDFunc(resourceIamBindingCreateUpdate(newUpdaterFunc, settings.EnableBatching)),
+		ReadContext:   tpgresource.ContextCRUDFunc(resourceIamBindingRead(newUpdaterFunc)),
+		UpdateContext: tpgresource.ContextCRUDFunc(resourceIamBindingCreateUpdate(newUpdaterFunc, settings.EnableBatching)),
+		DeleteContext: tpgresource.ContextCRUDFunc(resourceIamBindingDelete(newUpdaterFunc, settings.EnableBatching)),
 
 		// if non-empty, this will be used to send a deprecation message when the
 		// resource is used.
@@ -78,14 +79,14 @@ func ResourceIamBinding(parentSpecificSchema map[string]*schema.Schema, newUpdat
 
 		Schema: tpgresource.MergeSchemas(iamBindingSchema, parentSpecificSchema),
 		Importer: &schema.ResourceImporter{
-			State: iamBindingImport(newUpdaterFunc, resourceIdParser),
+			StateContext: iamBindingImport(newUpdaterFunc, resourceIdParser),
 		},
 		UseJSONNumber: true,
 	}
 }
 
-func resourceIamBindingCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(*schema.ResourceData, interface{}) error {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamBindingCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 		updater, err := newUpdaterFunc(d, config)
 		if err != nil {
@@ -104,7 +105,7 @@ func resourceIamBindingCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, en
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config, fmt.Sprintf(
 				"Set IAM Binding for role %q on %q", binding.Role, updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return err
@@ -114,12 +115,12 @@ func resourceIamBindingCreateUpdate(newUpdaterFunc NewResourceIamUpdaterFunc, en
 		if k := conditionKeyFromCondition(binding.Condition); !k.Empty() {
 			d.SetId(d.Id() + "/" + k.String())
 		}
-		return resourceIamBindingRead(newUpdaterFunc)(d, meta)
+		return resourceIamBindingRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
-func resourceIamBindingRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamBindingRead(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -129,7 +130,7 @@ func resourceIamBindingRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Rea
 
 		eBinding := getResourceIamBinding(d)
 		eCondition := conditionKeyFromCondition(eBinding.Condition)
-		p, err := iamPolicyReadWithRetry(updater)
+		p, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %q with IAM Binding (Role %q)", updater.DescribeResource(), eBinding.Role))
 		}
@@ -172,8 +173,8 @@ func resourceIamBindingRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Rea
 	}
 }
 
-func iamBindingImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser ResourceIdParserFunc) schema.StateFunc {
-	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func iamBindingImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser ResourceIdParserFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 		if resourceIdParser == nil {
 			return nil, errors.New("Import not supported for this IAM resource.")
 		}
@@ -216,7 +217,7 @@ func iamBindingImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser
 		if err != nil {
 			return nil, err
 		}
-		p, err := iamPolicyReadWithRetry(updater)
+		p, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return nil, err
 		}
@@ -252,8 +253,8 @@ func iamBindingImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser
 	}
 }
 
-func resourceIamBindingDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) schema.DeleteFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamBindingDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -271,13 +272,13 @@ func resourceIamBindingDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBa
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config, fmt.Sprintf(
 				"Delete IAM Binding for role %q on %q", binding.Role, updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %q for IAM binding with role %q", updater.DescribeResource(), binding.Role))
 		}
 
-		return resourceIamBindingRead(newUpdaterFunc)(d, meta)
+		return resourceIamBindingRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
@@ -1,6 +1,7 @@
 package tpgiamresource
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -87,8 +88,8 @@ var IamMemberBaseSchema = map[string]*schema.Schema{
 	},
 }
 
-func iamMemberImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser ResourceIdParserFunc) schema.StateFunc {
-	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func iamMemberImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser ResourceIdParserFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 		if resourceIdParser == nil {
 			return nil, errors.New("Import not supported for this IAM resource.")
 		}
@@ -131,7 +132,7 @@ func iamMemberImport(newUpdaterFunc NewResourceIamUpdaterFunc, resourceIdParser
 		if err != nil {
 			return nil, err
 		}
-		p, err := iamPolicyReadWithRetry(updater)
+		p, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return nil, err
 		}
@@ -173,9 +174,9 @@ func ResourceIamMember(parentSpecificSchema map[string]*schema.Schema, newUpdate
 	settings := NewIamSettings(options...)
 
 	return &schema.Resource{
-		Create: resourceIamMemberCreate(newUpdaterFunc, settings.EnableBatching),
-		Read:   resourceIamMemberRead(newUpdaterFunc),
-		Delete: resourceIamMemberDelete(newUpdaterFunc, settings.EnableBatching),
+		CreateContext: tpgresource.ContextCRUDFunc(resourceIamMemberCreate(newUpdaterFunc, settings.EnableBatching)),
+		ReadContext:   tpgresource.ContextCRUDFunc(resourceIamMemberRead(newUpdaterFunc)),
+		DeleteContext: tpgresource.ContextCRUDFunc(resourceIamMemberDelete(newUpdaterFunc, settings.EnableBatching)),
 
 		// if non-empty, this will be used to send a deprecation message when the
 		// resource is used.
@@ -183,7 +184,7 @@ func ResourceIamMember(parentSpecificSchema map[string]*schema.Schema, newUpdate
 
 		Schema: tpgresource.MergeSchemas(IamMemberBaseSchema, parentSpecificSchema),
 		Importer: &schema.ResourceImporter{
-			State: iamMemberImport(newUpdaterFunc, resourceIdParser),
+			StateContext: iamMemberImport(newUpdaterFunc, resourceIdParser),
 		},
 		UseJSONNumber: true,
 	}
@@ -200,8 +201,8 @@ func getResourceIamMember(d *schema.ResourceData) *cloudresourcemanager.Binding
 	return b
 }
 
-func resourceIamMemberCreate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) schema.CreateFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamMemberCreate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -220,7 +221,7 @@ func resourceIamMemberCreate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBat
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config,
 				fmt.Sprintf("Create IAM Members %s %+v for %s", memberBind.Role, memberBind.Members[0], updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return err
@@ -229,12 +230,12 @@ func resourceIamMemberCreate(newUpdaterFunc NewResourceIamUpdaterFunc, enableBat
 		if k := conditionKeyFromCondition(memberBind.Condition); !k.Empty() {
 			d.SetId(d.Id() + "/" + k.String())
 		}
-		return resourceIamMemberRead(newUpdaterFunc)(d, meta)
+		return resourceIamMemberRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
-func resourceIamMemberRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamMemberRead(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -244,7 +245,7 @@ func resourceIamMemberRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Read
 
 		eMember := getResourceIamMember(d)
 		eCondition := conditionKeyFromCondition(eMember.Condition)
-		p, err := iamPolicyReadWithRetry(updater)
+		p, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %q with IAM Member: Role %q Member %q", updater.DescribeResource(), eMember.Role, eMember.Members[0]))
 		}
@@ -295,8 +296,8 @@ func resourceIamMemberRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Read
 	}
 }
 
-func resourceIamMemberDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) schema.DeleteFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func resourceIamMemberDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBatching bool) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -314,11 +315,11 @@ func resourceIamMemberDelete(newUpdaterFunc NewResourceIamUpdaterFunc, enableBat
 			err = BatchRequestModifyIamPolicy(updater, modifyF, config,
 				fmt.Sprintf("Delete IAM Members %s %s for %q", memberBind.Role, memberBind.Members[0], updater.DescribeResource()))
 		} else {
-			err = iamPolicyReadModifyWrite(updater, modifyF)
+			err = iamPolicyReadModifyWrite(ctx, updater, modifyF)
 		}
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %s for IAM Member (role %q, %q)", updater.GetResourceId(), memberBind.Members[0], memberBind.Role))
 		}
-		return resourceIamMemberRead(newUpdaterFunc)(d, meta)
+		return resourceIamMemberRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
@@ -3,6 +3,7 @@ package tpgiamresource
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,10 +45,10 @@ func ResourceIamPolicy(parentSpecificSchema map[string]*schema.Schema, newUpdate
 	settings := NewIamSettings(options...)
 
 	return &schema.Resource{
-		Create: ResourceIamPolicyCreate(newUpdaterFunc),
-		Read:   ResourceIamPolicyRead(newUpdaterFunc),
-		Update: ResourceIamPolicyUpdate(newUpdaterFunc),
-		Delete: ResourceIamPolicyDelete(newUpdaterFunc),
+		CreateContext: tpgresource.ContextCRUDFunc(ResourceIamPolicyCreate(newUpdaterFunc)),
+		ReadContext:   tpgresource.ContextCRUDFunc(ResourceIamPolicyRead(newUpdaterFunc)),
+		UpdateContext: tpgresource.ContextCRUDFunc(ResourceIamPolicyUpdate(newUpdaterFunc)),
+		DeleteContext: tpgresource.ContextCRUDFunc(ResourceIamPolicyDelete(newUpdaterFunc)),
 
 		// if non-empty, this will be used to send a deprecation message when the
 		// resource is used.
@@ -61,8 +62,8 @@ func ResourceIamPolicy(parentSpecificSchema map[string]*schema.Schema, newUpdate
 	}
 }
 
-func ResourceIamPolicyCreate(newUpdaterFunc NewResourceIamUpdaterFunc) schema.CreateFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func ResourceIamPolicyCreate(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -70,17 +71,17 @@ func ResourceIamPolicyCreate(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Cr
 			return err
 		}
 
-		if err = setIamPolicyData(d, updater); err != nil {
+		if err = setIamPolicyData(ctx, d, updater); err != nil {
 			return err
 		}
 
 		d.SetId(updater.GetResourceId())
-		return ResourceIamPolicyRead(newUpdaterFunc)(d, meta)
+		return ResourceIamPolicyRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
-func ResourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func ResourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -88,7 +89,7 @@ func ResourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Read
 			return err
 		}
 
-		policy, err := iamPolicyReadWithRetry(updater)
+		policy, err := iamPolicyReadWithRetry(ctx, updater)
 		if err != nil {
 			return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("Resource %q with IAM Policy", updater.DescribeResource()))
 		}
@@ -104,8 +105,8 @@ func ResourceIamPolicyRead(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Read
 	}
 }
 
-func ResourceIamPolicyUpdate(newUpdaterFunc NewResourceIamUpdaterFunc) schema.UpdateFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func ResourceIamPolicyUpdate(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -114,17 +115,17 @@ func ResourceIamPolicyUpdate(newUpdaterFunc NewResourceIamUpdaterFunc) schema.Up
 		}
 
 		if d.HasChange("policy_data") {
-			if err := setIamPolicyData(d, updater); err != nil {
+			if err := setIamPolicyData(ctx, d, updater); err != nil {
 				return err
 			}
 		}
 
-		return ResourceIamPolicyRead(newUpdaterFunc)(d, meta)
+		return ResourceIamPolicyRead(newUpdaterFunc)(ctx, d, meta)
 	}
 }
 
-func ResourceIamPolicyDelete(newUpdaterFunc NewResourceIamUpdaterFunc) schema.DeleteFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
+func ResourceIamPolicyDelete(newUpdaterFunc NewResourceIamUpdaterFunc) func(context.Context, *schema.ResourceData, interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 		config := meta.(*transport_tpg.Config)
 
 		updater, err := newUpdaterFunc(d, config)
@@ -138,7 +139,7 @@ func ResourceIamPolicyDelete(newUpdaterFunc NewResourceIamUpdaterFunc) schema.De
 			pol.Etag = v.(string)
 		}
 		pol.Version = IamPolicyVersion
-		err = updater.SetResourceIamPolicy(pol)
+		err = setResourceIamPolicy(ctx, updater, pol)
 		if err != nil {
 			return err
 		}
@@ -147,14 +148,14 @@ func ResourceIamPolicyDelete(newUpdaterFunc NewResourceIamUpdaterFunc) schema.De
 	}
 }
 
-func setIamPolicyData(d *schema.ResourceData, updater ResourceIamUpdater) error {
+func setIamPolicyData(ctx context.Context, d *schema.ResourceData, updater ResourceIamUpdater) error {
 	policy, err := unmarshalIamPolicy(d.Get("policy_data").(string))
 	if err != nil {
 		return fmt.Errorf("'policy_data' is not valid for %s: %s", updater.DescribeResource(), err)
 	}
 	policy.Version = IamPolicyVersion
 
-	err = updater.SetResourceIamPolicy(policy)
+	err = setResourceIamPolicy(ctx, updater, policy)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -52,6 +53,16 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// ContextWaiter is a Waiter whose operation requests can be cancelled.
+// OperationWaitContext uses QueryOpContext instead of QueryOp when the waiter
+// implements it.
+type ContextWaiter interface {
+	Waiter
+
+	// QueryOpContext is QueryOp that sends its requests with ctx.
+	QueryOpContext(ctx context.Context) (interface{}, error)
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
 }
@@ -108,8 +119,18 @@ func OperationDone(w Waiter) bool {
 }
 
 func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
+	return commonRefreshFuncContext(context.Background(), w)
+}
+
+func commonRefreshFuncContext(ctx context.Context, w Waiter) retry.StateRefreshFunc {
+	queryOp := w.QueryOp
+	if cw, ok := w.(ContextWaiter); ok {
+		queryOp = func() (interface{}, error) {
+			return cw.QueryOpContext(ctx)
+		}
+	}
 	return func() (interface{}, string, error) {
-		op, err := w.QueryOp()
+		op, err := queryOp()
 		if err != nil {
 			// Retry 404 when getting operation (not resource state)
 			if transport_tpg.IsRetryableError(err, []transport_tpg.RetryErrorPredicateFunc{transport_tpg.IsNotFoundRetryableError("GET operation")}, nil) {
@@ -137,6 +158,11 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 }
 
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+	return OperationWaitContext(context.Background(), w, activity, timeout, pollInterval)
+}
+
+// OperationWaitContext is OperationWait that stops waiting when ctx is done.
+func OperationWaitContext(ctx context.Context, w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
 	if OperationDone(w) {
 		return w.Error()
 	}
@@ -144,12 +170,12 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 	c := &retry.StateChangeConf{
 		Pending:      w.PendingStates(),
 		Target:       w.TargetStates(),
-		Refresh:      CommonRefreshFunc(w),
+		Refresh:      commonRefreshFuncContext(ctx, w),
 		Timeout:      timeout,
 		MinTimeout:   2 * time.Second,
 		PollInterval: pollInterval,
 	}
-	opRaw, err := c.WaitForState()
+	opRaw, err := c.WaitForStateContext(ctx)
 	if err != nil {
 		return fmt.Errorf("Error waiting for %s: %w", activity, err)
 	}
@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"net/url"
 	"testing"
 	"time"
@@ -67,3 +68,42 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+// A ContextWaiter whose operation never finishes
+type TestContextWaiter struct {
+	TestWaiter
+	queriedWithContext bool
+}
+
+func (w *TestContextWaiter) State() string {
+	return "RUNNING"
+}
+
+func (TestContextWaiter) PendingStates() []string {
+	return []string{"RUNNING"}
+}
+
+func (w *TestContextWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
+	w.queriedWithContext = ctx.Value(testContextKey{}) != nil
+	return "my return value", nil
+}
+
+type testContextKey struct{}
+
+func TestOperationWaitContext_Cancelled(t *testing.T) {
+	testWaiter := &TestContextWaiter{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, true))
+	time.AfterFunc(500*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := OperationWaitContext(ctx, testWaiter, "my-activity", 1*time.Minute, 100*time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected an error when the context is cancelled, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the wait to stop when the context is cancelled, but it ran for %s", elapsed)
+	}
+	if !testWaiter.queriedWithContext {
+		t.Errorf("expected the operation to be queried with the context")
+	}
+}
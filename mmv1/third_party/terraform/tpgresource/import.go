@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -75,6 +77,61 @@ func ParseImportId(idRegexes []string, d TerraformResourceData, config *transpor
 	return fmt.Errorf("Import id %q doesn't match any of the accepted formats: %v", d.Id(), idRegexes)
 }
 
+// Parse the fields of a resource being imported, either from its import id
+// using ParseImportId, or, when it is imported by resource identity, from
+// the given attributes of the identity. Attributes are copied to the fields
+// of the same name, and project, region and zone default to the provider
+// configuration if they are unset.
+func ParseImportIdOrIdentity(idRegexes []string, identityFields []string, d *schema.ResourceData, config *transport_tpg.Config) error {
+	if d.Id() != "" {
+		return ParseImportId(idRegexes, d, config)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error reading resource identity: %s", err)
+	}
+	for _, field := range identityFields {
+		if v, ok := identity.GetOk(field); ok {
+			log.Printf("[DEBUG] importing %s = %v from resource identity", field, v)
+			if err := d.Set(field, v); err != nil {
+				return fmt.Errorf("Error setting %s: %s", field, err)
+			}
+		}
+	}
+	return setDefaultValues(idRegexes[0], d, config)
+}
+
+// Sets the id of a resource imported by resource identity from the given
+// import id format, so that custom importers can parse it like an import id.
+// Identity attributes are copied to the fields of the same name first, and
+// project, region and zone default to the provider configuration. Resources
+// imported by id are left unchanged.
+func SetImportIdFromIdentity(idFormat string, identityFields []string, d *schema.ResourceData, config *transport_tpg.Config) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error reading resource identity: %s", err)
+	}
+	for _, field := range identityFields {
+		if v, ok := identity.GetOk(field); ok {
+			if err := d.Set(field, v); err != nil {
+				return fmt.Errorf("Error setting %s: %s", field, err)
+			}
+		}
+	}
+	id, err := ReplaceVars(d, config, idFormat)
+	if err != nil {
+		return fmt.Errorf("Error constructing id from resource identity: %s", err)
+	}
+	log.Printf("[DEBUG] importing %s from resource identity", id)
+	d.SetId(id)
+	return nil
+}
+
 func setDefaultValues(idRegex string, d TerraformResourceData, config *transport_tpg.Config) error {
 	if _, ok := d.GetOk("project"); !ok && strings.Contains(idRegex, "?P<project>") {
 		project, err := GetProject(d, config)
@@ -663,6 +663,16 @@ func FrameworkDiagsToSdkDiags(fwD fwDiags.Diagnostics) *diag.Diagnostics {
 	return &diags
 }
 
+// ContextCRUDFunc adapts a CRUD function returning an error to the
+// CreateContext, ReadContext, UpdateContext and DeleteContext fields of a
+// resource. The SDK cancels the context when Terraform is interrupted or the
+// timeout of the operation expires.
+func ContextCRUDFunc(f func(context.Context, *schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(f(ctx, d, meta))
+	}
+}
+
 func IsEmptyValue(v reflect.Value) bool {
 	if !v.IsValid() {
 		return true
@@ -0,0 +1,308 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Strategies for combining the bodies of batched requests.
+const (
+	// BatchCombineListAppend concatenates the top-level lists of the bodies.
+	// Any other top-level field must have the same value in every body.
+	BatchCombineListAppend = "list_append"
+	// BatchCombineMapMerge merges the bodies recursively. A field set to
+	// different values in two bodies is an error.
+	BatchCombineMapMerge = "map_merge"
+)
+
+// Strategies for splitting the response to a batch between its requests.
+const (
+	// BatchSplitWhole returns the whole response to every request.
+	BatchSplitWhole = "whole"
+	// BatchSplitByIndex returns the items of a response list that are at the
+	// same offsets as the request's items in the combined body.
+	BatchSplitByIndex = "by_index"
+)
+
+type BatchedRequestOptions struct {
+	SendRequestOptions
+
+	// Batcher names the batcher in Config.RequestBatchers, typically the product.
+	Batcher string
+	// BatchKey groups requests that are sent together. Requests with the same
+	// key must have the same URL, method and project.
+	BatchKey string
+	// DebugId identifies the single request in logs and errors.
+	DebugId string
+
+	// CombineStrategy is one of BatchCombineListAppend or BatchCombineMapMerge.
+	CombineStrategy string
+	// SplitStrategy is one of BatchSplitWhole (the default) or BatchSplitByIndex.
+	SplitStrategy string
+	// BodyField, if set, wraps Body in a single item list under this field,
+	// for example "requests".
+	BodyField string
+	// ResponseField is the response list split by BatchSplitByIndex.
+	ResponseField string
+
+	// MutexKey, if set, is locked in MutexStore while the combined request
+	// is sent and waited on, so that it doesn't overlap other requests that
+	// take the same lock.
+	MutexKey string
+	// Wait, if set, is called with the response of the combined request
+	// before MutexKey is unlocked, for example to wait on an operation. Its
+	// error is only logged, since every caller waits on the response itself.
+	Wait func(res map[string]interface{}) error
+}
+
+// SendBatchedRequest sends the request through the named batcher, combined
+// with other requests with the same batch key. Items of a by_index response
+// that report an error fail only the request they belong to.
+func SendBatchedRequest(opt BatchedRequestOptions) (map[string]interface{}, error) {
+	if opt.Config == nil || opt.Config.RequestBatchers == nil {
+		return nil, fmt.Errorf("no request batchers configured for request to %s", opt.RawURL)
+	}
+
+	body := opt.Body
+	if opt.BodyField != "" {
+		body = map[string]interface{}{
+			opt.BodyField: []interface{}{opt.Body},
+		}
+	}
+
+	var combineF BatcherCombineFunc
+	switch opt.CombineStrategy {
+	case BatchCombineListAppend:
+		combineF = combineBatchListAppend
+	case BatchCombineMapMerge:
+		combineF = combineBatchMapMerge
+	default:
+		return nil, fmt.Errorf("unknown batch combine strategy %q", opt.CombineStrategy)
+	}
+
+	var splitF BatcherSplitFunc
+	switch opt.SplitStrategy {
+	case "", BatchSplitWhole:
+	case BatchSplitByIndex:
+		if opt.BodyField == "" || opt.ResponseField == "" {
+			return nil, fmt.Errorf("batch split strategy %q requires a body field and a response field", opt.SplitStrategy)
+		}
+		splitF = splitBatchByIndex(opt.BodyField, opt.ResponseField)
+	default:
+		return nil, fmt.Errorf("unknown batch split strategy %q", opt.SplitStrategy)
+	}
+
+	timeout := opt.Timeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	sendOpt := opt.SendRequestOptions
+	request := &BatchRequest{
+		ResourceName: opt.RawURL,
+		Body:         body,
+		CombineF:     combineF,
+		SendF: func(resourceName string, body interface{}) (interface{}, error) {
+			if opt.MutexKey != "" {
+				MutexStore.Lock(opt.MutexKey)
+				defer MutexStore.Unlock(opt.MutexKey)
+			}
+			sendOpt.RawURL = resourceName
+			sendOpt.Body, _ = body.(map[string]interface{})
+			res, err := SendRequest(sendOpt)
+			if err == nil && opt.Wait != nil {
+				if werr := opt.Wait(res); werr != nil {
+					log.Printf("[DEBUG] Error waiting on batched request %q: %s", opt.DebugId, werr)
+				}
+			}
+			return res, err
+		},
+		SplitF:  splitF,
+		DebugId: opt.DebugId,
+	}
+
+	v, err := opt.Config.RequestBatchers.Get(opt.Batcher).SendRequestWithTimeout(opt.BatchKey, request, timeout)
+	if err != nil {
+		return nil, err
+	}
+	res, _ := v.(map[string]interface{})
+	return res, nil
+}
+
+func combineBatchListAppend(currV interface{}, toAddV interface{}) (interface{}, error) {
+	curr, toAdd, err := batchBodies(currV, toAddV)
+	if err != nil {
+		return nil, err
+	}
+
+	combined := make(map[string]interface{}, len(curr))
+	for k, v := range curr {
+		combined[k] = v
+	}
+	for k, v := range toAdd {
+		currList, currIsList := batchList(combined[k])
+		addList, addIsList := batchList(v)
+		switch {
+		case currIsList && addIsList:
+			combined[k] = append(append([]interface{}{}, currList...), addList...)
+		case combined[k] == nil:
+			combined[k] = v
+		case !reflect.DeepEqual(combined[k], v):
+			return nil, fmt.Errorf("cannot combine requests with different values for field %q", k)
+		}
+	}
+	return combined, nil
+}
+
+// batchList returns the items of v if it is a slice of any type, such as the
+// []map[string]interface{} built by custom code.
+func batchList(v interface{}) ([]interface{}, bool) {
+	if l, ok := v.([]interface{}); ok {
+		return l, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	l := make([]interface{}, rv.Len())
+	for i := range l {
+		l[i] = rv.Index(i).Interface()
+	}
+	return l, true
+}
+
+func combineBatchMapMerge(currV interface{}, toAddV interface{}) (interface{}, error) {
+	curr, toAdd, err := batchBodies(currV, toAddV)
+	if err != nil {
+		return nil, err
+	}
+	return mergeBatchMaps(curr, toAdd, "")
+}
+
+func mergeBatchMaps(curr, toAdd map[string]interface{}, path string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(curr))
+	for k, v := range curr {
+		merged[k] = v
+	}
+	for k, v := range toAdd {
+		currMap, currIsMap := merged[k].(map[string]interface{})
+		addMap, addIsMap := v.(map[string]interface{})
+		switch {
+		case currIsMap && addIsMap:
+			m, err := mergeBatchMaps(currMap, addMap, path+k+".")
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = m
+		case merged[k] == nil:
+			merged[k] = v
+		case !reflect.DeepEqual(merged[k], v):
+			return nil, fmt.Errorf("cannot combine requests with different values for field %q", path+k)
+		}
+	}
+	return merged, nil
+}
+
+func batchBodies(currV interface{}, toAddV interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	curr, ok := currV.(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("provider error in batch combiner: expected data to be type map[string]interface{}, got %v with type %T", currV, currV)
+	}
+	toAdd, ok := toAddV.(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("provider error in batch combiner: expected data to be type map[string]interface{}, got %v with type %T", toAddV, toAddV)
+	}
+	return curr, toAdd, nil
+}
+
+// splitBatchByIndex returns a BatcherSplitFunc for responses that list one
+// item in responseField for each item listed in bodyField in the request.
+func splitBatchByIndex(bodyField, responseField string) BatcherSplitFunc {
+	return func(resp interface{}, bodies []interface{}, i int) (interface{}, error) {
+		offset := 0
+		for _, body := range bodies[:i] {
+			offset += len(batchBodyItems(body, bodyField))
+		}
+		count := len(batchBodyItems(bodies[i], bodyField))
+
+		res, _ := resp.(map[string]interface{})
+		items, _ := res[responseField].([]interface{})
+		if len(items) < offset+count {
+			return nil, fmt.Errorf("batch response has %d items in %q, expected at least %d", len(items), responseField, offset+count)
+		}
+		items = items[offset : offset+count]
+
+		for _, item := range items {
+			if err := batchItemError(item); err != nil {
+				return nil, err
+			}
+		}
+		if count == 1 {
+			item, _ := items[0].(map[string]interface{})
+			return item, nil
+		}
+		return map[string]interface{}{responseField: items}, nil
+	}
+}
+
+func batchBodyItems(body interface{}, bodyField string) []interface{} {
+	m, _ := body.(map[string]interface{})
+	items, _ := m[bodyField].([]interface{})
+	return items
+}
+
+// batchItemError returns the error reported by an item of a batch response,
+// either as an "error" or as a google.rpc.Status "status" with a non-zero code.
+func batchItemError(item interface{}) error {
+	m, _ := item.(map[string]interface{})
+	for _, field := range []string{"error", "status"} {
+		status, ok := m[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		code, _ := status["code"].(float64)
+		if field == "status" && code == 0 {
+			continue
+		}
+		message, _ := status["message"].(string)
+		return &googleapi.Error{
+			Code:    batchItemErrorCode(code),
+			Message: message,
+		}
+	}
+	return nil
+}
+
+// batchItemErrorCode converts a google.rpc.Code to a HTTP status code, so that
+// errors such as NOT_FOUND are handled like their HTTP equivalents.
+func batchItemErrorCode(code float64) int {
+	switch int(code) {
+	case 3, 9, 11:
+		return 400
+	case 16:
+		return 401
+	case 7:
+		return 403
+	case 5:
+		return 404
+	case 6, 10:
+		return 409
+	case 8:
+		return 429
+	case 1:
+		return 499
+	case 12:
+		return 501
+	case 14:
+		return 503
+	case 4:
+		return 504
+	case 2, 13, 15:
+		return 500
+	}
+	// Already a HTTP status code.
+	return int(code)
+}
@@ -0,0 +1,271 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCombineBatchListAppend(t *testing.T) {
+	combined, err := combineBatchListAppend(
+		map[string]interface{}{
+			"requests":     []interface{}{"a"},
+			"validateOnly": true,
+		},
+		map[string]interface{}{
+			"requests":     []interface{}{"b", "c"},
+			"validateOnly": true,
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"requests":     []interface{}{"a", "b", "c"},
+		"validateOnly": true,
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected %v, got %v", expected, combined)
+	}
+
+	combined, err = combineBatchListAppend(
+		map[string]interface{}{
+			"networkEndpoints": []map[string]interface{}{{"port": 80}},
+		},
+		map[string]interface{}{
+			"networkEndpoints": []map[string]interface{}{{"port": 443}},
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = map[string]interface{}{
+		"networkEndpoints": []interface{}{
+			map[string]interface{}{"port": 80},
+			map[string]interface{}{"port": 443},
+		},
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected %v, got %v", expected, combined)
+	}
+
+	_, err = combineBatchListAppend(
+		map[string]interface{}{"validateOnly": true},
+		map[string]interface{}{"validateOnly": false})
+	if err == nil || !strings.Contains(err.Error(), "validateOnly") {
+		t.Errorf("expected error for field validateOnly, got %v", err)
+	}
+}
+
+func TestCombineBatchMapMerge(t *testing.T) {
+	combined, err := combineBatchMapMerge(
+		map[string]interface{}{
+			"labels": map[string]interface{}{"a": "1"},
+			"name":   "n",
+		},
+		map[string]interface{}{
+			"labels": map[string]interface{}{"b": "2", "a": "1"},
+			"name":   "n",
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"labels": map[string]interface{}{"a": "1", "b": "2"},
+		"name":   "n",
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected %v, got %v", expected, combined)
+	}
+
+	_, err = combineBatchMapMerge(
+		map[string]interface{}{"labels": map[string]interface{}{"a": "1"}},
+		map[string]interface{}{"labels": map[string]interface{}{"a": "2"}})
+	if err == nil || !strings.Contains(err.Error(), "labels.a") {
+		t.Errorf("expected error for field labels.a, got %v", err)
+	}
+}
+
+func TestSplitBatchByIndex(t *testing.T) {
+	split := splitBatchByIndex("requests", "responses")
+	bodies := []interface{}{
+		map[string]interface{}{"requests": []interface{}{"a"}},
+		map[string]interface{}{"requests": []interface{}{"b"}},
+		map[string]interface{}{"requests": []interface{}{"c"}},
+	}
+	resp := map[string]interface{}{
+		"responses": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"error": map[string]interface{}{"code": float64(409), "message": "b already exists"}},
+			map[string]interface{}{"name": "c", "status": map[string]interface{}{"code": float64(5), "message": "c not found"}},
+		},
+	}
+
+	v, err := split(resp, bodies, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(v, map[string]interface{}{"name": "a"}) {
+		t.Errorf("expected the first item, got %v", v)
+	}
+
+	if _, err := split(resp, bodies, 1); !IsGoogleApiErrorWithCode(err, 409) {
+		t.Errorf("expected a 409 error for the second item, got %v", err)
+	}
+	if _, err := split(resp, bodies, 2); !IsGoogleApiErrorWithCode(err, 404) {
+		t.Errorf("expected a 404 error for the third item, got %v", err)
+	}
+
+	short := map[string]interface{}{"responses": []interface{}{}}
+	if _, err := split(short, bodies, 0); err == nil {
+		t.Errorf("expected error for a response with missing items")
+	}
+}
+
+func TestSendBatchedRequest(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var body map[string][]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var responses []interface{}
+		for _, item := range body["requests"] {
+			if item["name"] == "bad" {
+				responses = append(responses, map[string]interface{}{
+					"error": map[string]interface{}{"code": 3, "message": "invalid name"},
+				})
+				continue
+			}
+			responses = append(responses, map[string]interface{}{"name": item["name"], "created": true})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer ts.Close()
+
+	config := &Config{
+		Client: ts.Client(),
+		RequestBatchers: NewRequestBatcherRegistry(context.Background(), &BatchingConfig{
+			SendAfter:      time.Second,
+			EnableBatching: true,
+		}),
+	}
+
+	names := []string{"a", "bad", "c"}
+	wg := sync.WaitGroup{}
+	wg.Add(len(names))
+	for _, name := range names {
+		go func(name string) {
+			defer wg.Done()
+
+			res, err := SendBatchedRequest(BatchedRequestOptions{
+				SendRequestOptions: SendRequestOptions{
+					Config: config,
+					Method: "POST",
+					RawURL: ts.URL + "/v1/things:batchCreate",
+					Body:   map[string]interface{}{"name": name},
+				},
+				Batcher:         "Test",
+				BatchKey:        "things:batchCreate",
+				DebugId:         fmt.Sprintf("create %s", name),
+				CombineStrategy: BatchCombineListAppend,
+				SplitStrategy:   BatchSplitByIndex,
+				BodyField:       "requests",
+				ResponseField:   "responses",
+			})
+			if name == "bad" {
+				if !IsGoogleApiErrorWithCode(err, 400) {
+					t.Errorf("expected a 400 error for %q, got %v", name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected %q to succeed, got error: %v", name, err)
+			} else if res["name"] != name || res["created"] != true {
+				t.Errorf("expected the response item for %q, got %v", name, res)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("expected 1 batched request, got %d", requests)
+	}
+}
+
+func TestSendBatchedRequest_mutexKey(t *testing.T) {
+	var active, maxActive, requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": "operation"})
+	}))
+	defer ts.Close()
+
+	config := &Config{
+		Client: ts.Client(),
+		RequestBatchers: NewRequestBatcherRegistry(context.Background(), &BatchingConfig{
+			SendAfter:      100 * time.Millisecond,
+			EnableBatching: true,
+		}),
+	}
+
+	// wait stands for an operation wait, and tracks how many batches are
+	// in progress at once.
+	wait := func(_ map[string]interface{}) error {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	}
+
+	// The second request starts a new batch with the same key while the
+	// first batch is waited on.
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	for i, delay := range []time.Duration{0, 300 * time.Millisecond} {
+		go func(i int, delay time.Duration) {
+			defer wg.Done()
+			time.Sleep(delay)
+
+			_, err := SendBatchedRequest(BatchedRequestOptions{
+				SendRequestOptions: SendRequestOptions{
+					Config: config,
+					Method: "POST",
+					RawURL: ts.URL + "/v1/things:attach",
+					Body:   map[string]interface{}{"things": []interface{}{i}},
+				},
+				Batcher:         "Test",
+				BatchKey:        "things:attach",
+				DebugId:         fmt.Sprintf("attach %d", i),
+				CombineStrategy: BatchCombineListAppend,
+				MutexKey:        "things",
+				Wait:            wait,
+			})
+			if err != nil {
+				t.Errorf("expected request %d to succeed, got error: %v", i, err)
+			}
+		}(i, delay)
+	}
+	wg.Wait()
+
+	if requests != 2 {
+		t.Errorf("expected 2 batched requests, got %d", requests)
+	}
+	if maxActive != 1 {
+		t.Errorf("expected batches with the same mutex key not to overlap, got %d at once", maxActive)
+	}
+}
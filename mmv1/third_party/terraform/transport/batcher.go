@@ -2,6 +2,7 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -50,6 +51,11 @@ type (
 		// Bodies.
 		SendF BatcherSendFunc
 
+		// SplitF function, if set, determines how to get this request's result
+		// from the response to a batch. It lets a batch that partially failed
+		// return an error only to the requests whose items failed.
+		SplitF BatcherSplitFunc
+
 		// ID for debugging request. This should be specific to a single request
 		// (i.e. per Terraform resource)
 		DebugId string
@@ -60,6 +66,10 @@ type (
 
 	// BatcherSendFunc is a function type for sending a batch request
 	BatcherSendFunc func(resourceName string, body interface{}) (interface{}, error)
+
+	// BatcherSplitFunc is a function type for getting the result for the i-th of
+	// the request bodies combined into a batch from the batch response
+	BatcherSplitFunc func(resp interface{}, bodies []interface{}, i int) (interface{}, error)
 )
 
 // batchResponse bundles an API response (data, error) tuple.
@@ -123,6 +133,37 @@ func NewRequestBatcher(debugId string, ctx context.Context, config *BatchingConf
 	return batcher
 }
 
+// RequestBatcherRegistry holds the batchers used by generated resources. It
+// creates one batcher per name on first use, so that services don't block
+// each other's batches.
+type RequestBatcherRegistry struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	config   *BatchingConfig
+	batchers map[string]*RequestBatcher
+}
+
+func NewRequestBatcherRegistry(ctx context.Context, config *BatchingConfig) *RequestBatcherRegistry {
+	return &RequestBatcherRegistry{
+		ctx:      ctx,
+		config:   config,
+		batchers: make(map[string]*RequestBatcher),
+	}
+}
+
+// Get returns the batcher with the given name, creating it if needed.
+func (r *RequestBatcherRegistry) Get(name string) *RequestBatcher {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.batchers[name]
+	if !ok {
+		b = NewRequestBatcher(name, r.ctx, r.config)
+		r.batchers[name] = b
+	}
+	return b
+}
+
 func (b *RequestBatcher) stop() {
 	b.Lock()
 	defer b.Unlock()
@@ -163,7 +204,8 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 	}
 	if !b.EnableBatching {
 		log.Printf("[DEBUG] Batching is disabled, sending single request for %q", request.DebugId)
-		return request.SendF(request.ResourceName, request.Body)
+		resp := request.sendSingle()
+		return resp.body, resp.err
 	}
 
 	respCh, err := b.registerBatchRequest(batchKey, request)
@@ -215,7 +257,20 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 
 	// If batch already exists, combine this request into existing request.
 	if batch, ok := b.batches[batchKey]; ok {
-		return batch.addRequest(newRequest)
+		respCh, err := batch.addRequest(newRequest)
+		var combineErr *batchCombineError
+		if !errors.As(err, &combineErr) {
+			return respCh, err
+		}
+		// Requests that can't be combined with the batch are sent alone
+		// instead of failing.
+		log.Printf("[DEBUG] %v, sending request %q alone", err, newRequest.DebugId)
+		singleCh := make(chan batchResponse, 1)
+		go func() {
+			singleCh <- newRequest.sendSingle()
+			close(singleCh)
+		}()
+		return singleCh, nil
 	}
 
 	// Batch doesn't exist for given batch key - create a new batch.
@@ -236,6 +291,7 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 			Body:         newRequest.Body,
 			CombineF:     newRequest.CombineF,
 			SendF:        newRequest.SendF,
+			SplitF:       newRequest.SplitF,
 			DebugId:      fmt.Sprintf("Combined batch for started batch %q", batchKey),
 		},
 		batchKey:    batchKey,
@@ -247,7 +303,7 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 		batch := b.popBatch(batchKey)
 		if batch == nil {
 			log.Printf("[ERROR] batch should have been added to saved batches - just run as single request %q", newRequest.DebugId)
-			respCh <- newRequest.send()
+			respCh <- newRequest.sendSingle()
 			close(respCh)
 		} else {
 			b.sendBatchWithSingleRetry(batchKey, batch)
@@ -267,7 +323,7 @@ func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *starte
 		log.Printf("[DEBUG] Sending each request in batch separately")
 		for _, sub := range batch.subscribers {
 			log.Printf("[DEBUG] Retrying single request %q", sub.singleRequest.DebugId)
-			singleResp := sub.singleRequest.send()
+			singleResp := sub.singleRequest.sendSingle()
 			log.Printf("[DEBUG] Retried single request %q returned response: %v", sub.singleRequest.DebugId, singleResp)
 
 			if singleResp.IsError() {
@@ -279,9 +335,13 @@ func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *starte
 			close(sub.respCh)
 		}
 	} else {
-		// Send result to all subscribers
+		// Send result to all subscribers, split per request if possible.
+		bodies := make([]interface{}, 0, len(batch.subscribers))
 		for _, sub := range batch.subscribers {
-			sub.respCh <- resp
+			bodies = append(bodies, sub.singleRequest.Body)
+		}
+		for i, sub := range batch.subscribers {
+			sub.respCh <- batch.split(resp, bodies, i)
 			close(sub.respCh)
 		}
 	}
@@ -310,7 +370,9 @@ func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchRes
 	}
 	newBody, err := batch.CombineF(batch.Body, newRequest.Body)
 	if err != nil {
-		return nil, fmt.Errorf("Provider Error: Unable to combine request %q data into existing batch %q: %v", newRequest.DebugId, batch.batchKey, err)
+		return nil, &batchCombineError{
+			fmt.Errorf("unable to combine request %q data into existing batch %q: %v", newRequest.DebugId, batch.batchKey, err),
+		}
 	}
 	batch.Body = newBody
 
@@ -325,6 +387,11 @@ func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchRes
 	return respCh, nil
 }
 
+// batchCombineError is returned by addRequest when CombineF fails.
+type batchCombineError struct {
+	error
+}
+
 func (req *BatchRequest) send() batchResponse {
 	if req.SendF == nil {
 		return batchResponse{
@@ -334,3 +401,18 @@ func (req *BatchRequest) send() batchResponse {
 	v, err := req.SendF(req.ResourceName, req.Body)
 	return batchResponse{v, err}
 }
+
+// sendSingle sends the request alone, outside of a batch.
+func (req *BatchRequest) sendSingle() batchResponse {
+	return req.split(req.send(), []interface{}{req.Body}, 0)
+}
+
+// split returns the result of the i-th of bodies from a successful response
+// to a request combining all of them.
+func (req *BatchRequest) split(resp batchResponse, bodies []interface{}, i int) batchResponse {
+	if req.SplitF == nil || resp.IsError() {
+		return resp
+	}
+	v, err := req.SplitF(resp.body, bodies, i)
+	return batchResponse{v, err}
+}
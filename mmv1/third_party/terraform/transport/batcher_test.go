@@ -76,38 +76,39 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 			EnableBatching: true,
 		})
 
-	combineErrText := "this is an expected error in combine"
 	testCombine := func(_ interface{}, _ interface{}) (interface{}, error) {
-		return nil, errors.New(combineErrText)
+		return nil, errors.New("this is an expected error in combine")
 	}
 
-	// sendBatchF is no-op
-	testSendBatch := func(_ string, _ interface{}) (interface{}, error) {
-		return nil, nil
+	// sendBatchF returns the request body
+	testSendBatch := func(_ string, body interface{}) (interface{}, error) {
+		return body, nil
 	}
 
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 
-	// First call should no-op.
+	// First call should be sent as a batch.
 	go func() {
 		defer wg.Done()
 
 		req := &BatchRequest{
 			DebugId:      "errInCombine first",
 			ResourceName: "test-resource",
-			Body:         nil,
+			Body:         "first",
 			CombineF:     testCombine,
 			SendF:        testSendBatch,
 		}
 
-		_, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
+		resp, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
 		if err != nil {
 			t.Errorf("expected no error, got: %s", err)
+		} else if resp != "first" {
+			t.Errorf("expected response %q, got %v", "first", resp)
 		}
 	}()
 
-	// Second call should fail when being combined with original batch
+	// Second call can't be combined with original batch and should be sent alone
 	go func() {
 		time.Sleep(time.Second)
 		defer wg.Done()
@@ -115,16 +116,16 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 		req := &BatchRequest{
 			DebugId:      "errInCombine second",
 			ResourceName: "test-resource",
-			Body:         nil,
+			Body:         "second",
 			CombineF:     testCombine,
 			SendF:        testSendBatch,
 		}
 
-		_, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
-		if err == nil {
-			t.Errorf("expected error, got none")
-		} else if !strings.Contains(err.Error(), combineErrText) {
-			t.Errorf("error does not contain expected error %s. Got: %s", combineErrText, err)
+		resp, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
+		if err != nil {
+			t.Errorf("expected no error, got: %s", err)
+		} else if resp != "second" {
+			t.Errorf("expected response %q, got %v", "second", resp)
 		}
 	}()
 
@@ -200,6 +201,84 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 	wg.Wait()
 }
 
+func TestRequestBatcher_splitResponse(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(1) * time.Second,
+			EnableBatching: true,
+		})
+
+	testCombine := func(body interface{}, toAdd interface{}) (interface{}, error) {
+		return append(body.([]int), toAdd.([]int)...), nil
+	}
+
+	// sendBatchF doubles each value
+	testSendBatch := func(_ string, body interface{}) (interface{}, error) {
+		var resp []int
+		for _, v := range body.([]int) {
+			resp = append(resp, v*2)
+		}
+		return resp, nil
+	}
+
+	// splitF returns the doubled value of the request, or an error for odd values
+	testSplit := func(resp interface{}, bodies []interface{}, i int) (interface{}, error) {
+		v := resp.([]int)[i]
+		if v%4 != 0 {
+			return nil, fmt.Errorf("item %d failed", bodies[i].([]int)[0])
+		}
+		return v, nil
+	}
+
+	numRequests := 4
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest{
+				DebugId:      fmt.Sprintf("splitResponse %d", idx),
+				ResourceName: "test-resource",
+				Body:         []int{idx},
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+				SplitF:       testSplit,
+			}
+
+			respV, err := testBatcher.SendRequestWithTimeout("batchSplit", req, time.Duration(10)*time.Second)
+			if idx%2 != 0 {
+				if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("item %d failed", idx)) {
+					t.Errorf("expected only request %d to get its item error, got %v", idx, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected request %d to succeed, got error: %v", idx, err)
+			} else if respV != idx*2 {
+				t.Errorf("expected response %d for request %d, got %v", idx*2, idx, respV)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestRequestBatcherRegistry(t *testing.T) {
+	registry := NewRequestBatcherRegistry(context.Background(), &BatchingConfig{})
+
+	if registry.Get("Compute") != registry.Get("Compute") {
+		t.Errorf("expected the same batcher for the same name")
+	}
+	if registry.Get("Compute") == registry.Get("DNS") {
+		t.Errorf("expected different batchers for different names")
+	}
+}
+
 func TestRequestBatcher_errTimeout(t *testing.T) {
 	testBatcher := NewRequestBatcher(
 		"testBatcher",
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sync"
@@ -33,16 +34,24 @@ func SuccessPollResult() PollResult {
 }
 
 func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
+	timeout time.Duration, targetOccurrences int) error {
+	return PollingWaitTimeContext(context.Background(), pollF, checkResponse, activity, timeout, targetOccurrences)
+}
+
+// PollingWaitTimeContext is PollingWaitTime that stops polling when ctx is done.
+// pollF should send its requests with the same ctx so that the request in
+// flight is cancelled too.
+func PollingWaitTimeContext(ctx context.Context, pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
 	timeout time.Duration, targetOccurrences int) error {
 	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
 	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
 	if targetOccurrences == 1 {
-		return retry.Retry(timeout, func() *retry.RetryError {
+		return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 			readResp, readErr := pollF()
 			return checkResponse(readResp, readErr)
 		})
 	}
-	return RetryWithTargetOccurrences(timeout, targetOccurrences, func() *retry.RetryError {
+	return RetryWithTargetOccurrencesContext(ctx, timeout, targetOccurrences, func() *retry.RetryError {
 		readResp, readErr := pollF()
 		return checkResponse(readResp, readErr)
 	})
@@ -52,6 +61,13 @@ func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, ac
 // a function until it returns the specified amount of target occurrences continuously.
 // Adapted from the Retry function in the go SDK.
 func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
+	f retry.RetryFunc) error {
+	return RetryWithTargetOccurrencesContext(context.Background(), timeout, targetOccurrences, f)
+}
+
+// RetryWithTargetOccurrencesContext is RetryWithTargetOccurrences that stops
+// retrying when ctx is done.
+func RetryWithTargetOccurrencesContext(ctx context.Context, timeout time.Duration, targetOccurrences int,
 	f retry.RetryFunc) error {
 	// These are used to pull the error out of the function; need a mutex to
 	// avoid a data race.
@@ -84,7 +100,7 @@ func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 		},
 	}
 
-	_, waitErr := c.WaitForState()
+	_, waitErr := c.WaitForStateContext(ctx)
 
 	// Need to acquire the lock here to be able to avoid race using resultErr as
 	// the return value
@@ -0,0 +1,160 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig controls client-side throttling of API requests. It is
+// configured by the provider-level `rate_limit` block.
+type RateLimitConfig struct {
+	// RequestsPerSecond limits requests to each API host and consumer project.
+	// Zero means requests are not limited.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once before the
+	// limit applies. Zero uses the requests per second, rounded up.
+	Burst int
+	// ServiceRequestsPerSecond overrides RequestsPerSecond for API hosts such
+	// as "compute.googleapis.com". Zero means requests to the host are not limited.
+	ServiceRequestsPerSecond map[string]float64
+}
+
+func ExpandProviderRateLimitConfig(v interface{}) (*RateLimitConfig, error) {
+	config := &RateLimitConfig{}
+
+	if v == nil {
+		return config, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return config, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if rps, ok := cfgV["requests_per_second"]; ok {
+		config.RequestsPerSecond = rps.(float64)
+	}
+
+	if burst, ok := cfgV["burst"]; ok {
+		config.Burst = burst.(int)
+	}
+
+	if overrides, ok := cfgV["service_requests_per_second"]; ok {
+		for host, rps := range overrides.(map[string]interface{}) {
+			if strings.Contains(host, "/") {
+				return nil, fmt.Errorf("'service_requests_per_second' keys must be API host names such as \"compute.googleapis.com\", got %q", host)
+			}
+			if config.ServiceRequestsPerSecond == nil {
+				config.ServiceRequestsPerSecond = make(map[string]float64)
+			}
+			config.ServiceRequestsPerSecond[host] = rps.(float64)
+		}
+	}
+
+	return config, nil
+}
+
+// Enabled reports whether any requests are limited.
+func (c *RateLimitConfig) Enabled() bool {
+	return c != nil && (c.RequestsPerSecond > 0 || len(c.ServiceRequestsPerSecond) > 0)
+}
+
+func (c *RateLimitConfig) String() string {
+	hosts := make([]string, 0, len(c.ServiceRequestsPerSecond))
+	for host := range c.ServiceRequestsPerSecond {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	overrides := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		overrides = append(overrides, fmt.Sprintf("%s=%g", host, c.ServiceRequestsPerSecond[host]))
+	}
+	return fmt.Sprintf("requests_per_second=%g burst=%d service_requests_per_second=[%s]",
+		c.RequestsPerSecond, c.Burst, strings.Join(overrides, " "))
+}
+
+// requestsPerSecond returns the limit for requests to host.
+func (c *RateLimitConfig) requestsPerSecond(host string) float64 {
+	if rps, ok := c.ServiceRequestsPerSecond[host]; ok {
+		return rps
+	}
+	return c.RequestsPerSecond
+}
+
+func (c *RateLimitConfig) burst(rps float64) int {
+	if c.Burst > 0 {
+		return c.Burst
+	}
+	return int(math.Max(1, math.Ceil(rps)))
+}
+
+// rateLimitKey identifies a bucket of requests sharing a quota.
+type rateLimitKey struct {
+	host    string
+	project string
+}
+
+// rateLimitTransport is a http.RoundTripper that waits for a token from a
+// token bucket before sending each request. Requests are bucketed by API
+// host and consumer project (the X-Goog-User-Project header), which is how
+// most per-minute quotas are enforced.
+type rateLimitTransport struct {
+	config      *RateLimitConfig
+	baseTransit http.RoundTripper
+
+	mu       sync.Mutex
+	limiters map[rateLimitKey]*rate.Limiter
+}
+
+func NewTransportWithRateLimit(baseTransit http.RoundTripper, config *RateLimitConfig) *rateLimitTransport {
+	if baseTransit == nil {
+		baseTransit = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		config:      config,
+		baseTransit: baseTransit,
+		limiters:    make(map[rateLimitKey]*rate.Limiter),
+	}
+}
+
+// limiter returns the limiter for key, or nil if requests for key are not limited.
+func (t *rateLimitTransport) limiter(key rateLimitKey) *rate.Limiter {
+	rps := t.config.requestsPerSecond(key.host)
+	if rps <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.limiters[key]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(rps), t.config.burst(rps))
+		t.limiters[key] = l
+	}
+	return l
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := rateLimitKey{
+		host:    req.URL.Host,
+		project: req.Header.Get("X-Goog-User-Project"),
+	}
+	if l := t.limiter(key); l != nil {
+		start := time.Now()
+		if err := l.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limit for %s (project %q): %w", key.host, key.project, err)
+		}
+		if waited := time.Since(start); waited >= time.Millisecond {
+			log.Printf("[DEBUG] Rate Limit Transport: waited %s before sending request to %s (project %q)", waited.Round(time.Millisecond), key.host, key.project)
+		}
+	}
+	return t.baseTransit.RoundTrip(req)
+}
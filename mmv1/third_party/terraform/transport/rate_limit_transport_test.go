@@ -0,0 +1,175 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExpandProviderRateLimitConfig(t *testing.T) {
+	config, err := ExpandProviderRateLimitConfig(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Enabled() {
+		t.Errorf("expected rate limit to be disabled by default, got %s", config)
+	}
+
+	config, err = ExpandProviderRateLimitConfig([]interface{}{
+		map[string]interface{}{
+			"requests_per_second": 2.5,
+			"burst":               0,
+			"service_requests_per_second": map[string]interface{}{
+				"compute.googleapis.com": 10.0,
+				"storage.googleapis.com": 0.0,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Enabled() {
+		t.Errorf("expected rate limit to be enabled")
+	}
+
+	expected := "requests_per_second=2.5 burst=0 service_requests_per_second=[compute.googleapis.com=10 storage.googleapis.com=0]"
+	if got := config.String(); got != expected {
+		t.Errorf("expected config %s, got %s", expected, got)
+	}
+
+	cases := map[string]struct {
+		rps   float64
+		burst int
+	}{
+		"compute.googleapis.com":  {rps: 10, burst: 10},
+		"storage.googleapis.com":  {rps: 0},
+		"pubsub.googleapis.com":   {rps: 2.5, burst: 3},
+		"cloudkms.googleapis.com": {rps: 2.5, burst: 3},
+	}
+	for host, tc := range cases {
+		rps := config.requestsPerSecond(host)
+		if rps != tc.rps {
+			t.Errorf("expected %g requests per second for %s, got %g", tc.rps, host, rps)
+		}
+		if rps > 0 && config.burst(rps) != tc.burst {
+			t.Errorf("expected burst %d for %s, got %d", tc.burst, host, config.burst(rps))
+		}
+	}
+}
+
+func TestExpandProviderRateLimitConfig_invalidHost(t *testing.T) {
+	_, err := ExpandProviderRateLimitConfig([]interface{}{
+		map[string]interface{}{
+			"service_requests_per_second": map[string]interface{}{
+				"https://compute.googleapis.com/compute/v1/": 10.0,
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected error for a URL key")
+	}
+}
+
+func TestRateLimitTransport_throttlesPerHostAndProject(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = NewTransportWithRateLimit(client.Transport, &RateLimitConfig{
+		RequestsPerSecond: 5,
+		Burst:             1,
+	})
+
+	send := func(project string) {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatalf("unable to construct request: %v", err)
+		}
+		if project != "" {
+			req.Header.Set("X-Goog-User-Project", project)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// The first request for each project uses its own burst token.
+	start := time.Now()
+	send("project-a")
+	send("project-b")
+	if elapsed := time.Since(start); elapsed >= 150*time.Millisecond {
+		t.Errorf("expected requests for different projects not to wait for each other, took %s", elapsed)
+	}
+
+	// Following requests for the same project wait for a new token.
+	start = time.Now()
+	send("project-a")
+	send("project-a")
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("expected requests for the same project to be throttled to 5 per second, took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_unlimitedService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	transport := NewTransportWithRateLimit(client.Transport, &RateLimitConfig{
+		ServiceRequestsPerSecond: map[string]float64{"compute.googleapis.com": 1},
+	})
+	client.Transport = transport
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected requests to hosts without a limit not to wait, took %s", elapsed)
+	}
+	if len(transport.limiters) != 0 {
+		t.Errorf("expected no limiters for hosts without a limit, got %d", len(transport.limiters))
+	}
+}
+
+func TestRateLimitTransport_contextCancelled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = NewTransportWithRateLimit(client.Transport, &RateLimitConfig{
+		RequestsPerSecond: 0.1,
+		Burst:             1,
+	})
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled error, got %v", err)
+	}
+}
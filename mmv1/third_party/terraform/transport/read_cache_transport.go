@@ -0,0 +1,247 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ReadCacheConfig controls the provider's read cache. It is configured by the
+// provider-level `read_cache` block.
+type ReadCacheConfig struct {
+	Enabled bool
+}
+
+func ExpandProviderReadCacheConfig(v interface{}) (*ReadCacheConfig, error) {
+	config := &ReadCacheConfig{}
+
+	if v == nil {
+		return config, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return config, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if enabled, ok := cfgV["enabled"]; ok {
+		config.Enabled = enabled.(bool)
+	}
+
+	return config, nil
+}
+
+type readCacheContextKey struct{}
+
+// withReadCache marks requests sent with the returned context as reads that
+// can be served from the read cache.
+func withReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheContextKey{}, true)
+}
+
+func isReadCacheable(req *http.Request) bool {
+	cacheable, _ := req.Context().Value(readCacheContextKey{}).(bool)
+	return cacheable && req.Method == http.MethodGet
+}
+
+// readCacheEntry holds the response to a cached read. done is closed once the
+// response, or the error, is available.
+type readCacheEntry struct {
+	resource string
+	done     chan struct{}
+
+	resp *http.Response
+	body []byte
+	err  error
+	// canceled is set when err comes from the context of the request that
+	// was sent, which was canceled or timed out.
+	canceled bool
+}
+
+func (e *readCacheEntry) response(req *http.Request) *http.Response {
+	resp := *e.resp
+	resp.Header = e.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(e.body))
+	resp.Request = req
+	return &resp
+}
+
+// readCacheTransport is a http.RoundTripper that shares the responses to
+// identical reads for the lifetime of the provider. Concurrent identical reads
+// wait for a single request.
+//
+// Only GET requests marked with withReadCache are cached, and only successful
+// responses are kept. Any other request is treated as a mutation: it evicts
+// cached reads of the same resource, its parents and its children, and those
+// reads skip the cache from then on, since long-running operations may still
+// change them after the request returns.
+type readCacheTransport struct {
+	baseTransit http.RoundTripper
+
+	mu      sync.Mutex
+	entries map[string]*readCacheEntry
+	mutated []string
+	// generation is incremented by every mutation, so that reads that were
+	// in flight at the time are not kept.
+	generation int
+
+	hits, misses, bypassed int
+}
+
+func NewTransportWithReadCache(baseTransit http.RoundTripper) *readCacheTransport {
+	if baseTransit == nil {
+		baseTransit = http.DefaultTransport
+	}
+	return &readCacheTransport{
+		baseTransit: baseTransit,
+		entries:     make(map[string]*readCacheEntry),
+	}
+}
+
+// readCacheResource identifies the resource a request reads.
+func readCacheResource(req *http.Request) string {
+	return req.URL.Host + req.URL.Path
+}
+
+// mutatedResource identifies the resource a request mutates. Custom methods
+// such as ":batchCreate" are removed so that the mutation is related to the
+// resource's children.
+func mutatedResource(req *http.Request) string {
+	resource := readCacheResource(req)
+	if i := strings.LastIndex(resource, ":"); i > strings.LastIndex(resource, "/") {
+		resource = resource[:i]
+	}
+	return resource
+}
+
+// readCacheKey identifies identical reads: the same URL and the same headers.
+func readCacheKey(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.URL.String())
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString("\n" + name + ": " + strings.Join(req.Header[name], ", "))
+	}
+	return b.String()
+}
+
+// relatedResources reports whether a mutation of one resource may change the other.
+func relatedResources(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+func (t *readCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case isReadCacheable(req):
+		return t.read(req)
+	case req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions:
+		return t.baseTransit.RoundTrip(req)
+	default:
+		t.invalidate(req)
+		return t.baseTransit.RoundTrip(req)
+	}
+}
+
+func (t *readCacheTransport) read(req *http.Request) (*http.Response, error) {
+	resource := readCacheResource(req)
+	key := readCacheKey(req)
+
+	t.mu.Lock()
+	for _, mutated := range t.mutated {
+		if relatedResources(resource, mutated) {
+			t.bypassed++
+			t.logStats("bypassed", req)
+			t.mu.Unlock()
+			return t.baseTransit.RoundTrip(req)
+		}
+	}
+	if e, ok := t.entries[key]; ok {
+		t.hits++
+		t.logStats("hit", req)
+		t.mu.Unlock()
+
+		select {
+		case <-e.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if e.canceled {
+			// The context of the shared request doesn't apply to this one,
+			// so read again.
+			return t.read(req)
+		}
+		if e.err != nil {
+			return nil, e.err
+		}
+		return e.response(req), nil
+	}
+	e := &readCacheEntry{
+		resource: resource,
+		done:     make(chan struct{}),
+	}
+	t.entries[key] = e
+	generation := t.generation
+	t.misses++
+	t.logStats("miss", req)
+	t.mu.Unlock()
+
+	resp, err := t.baseTransit.RoundTrip(req)
+	if err == nil {
+		e.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		e.resp = resp
+	}
+	e.err = err
+	e.canceled = err != nil && req.Context().Err() != nil
+
+	t.mu.Lock()
+	if err != nil || resp.StatusCode != http.StatusOK || generation != t.generation {
+		if t.entries[key] == e {
+			delete(t.entries, key)
+		}
+	}
+	t.mu.Unlock()
+	close(e.done)
+
+	if err != nil {
+		return nil, err
+	}
+	return e.response(req), nil
+}
+
+func (t *readCacheTransport) invalidate(req *http.Request) {
+	resource := mutatedResource(req)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.generation++
+	evicted := 0
+	for key, e := range t.entries {
+		if relatedResources(e.resource, resource) {
+			delete(t.entries, key)
+			evicted++
+		}
+	}
+	for _, mutated := range t.mutated {
+		if mutated == resource {
+			return
+		}
+	}
+	t.mutated = append(t.mutated, resource)
+	log.Printf("[DEBUG] Read Cache: %s %s evicted %d cached reads", req.Method, req.URL, evicted)
+}
+
+// logStats must be called with t.mu held.
+func (t *readCacheTransport) logStats(result string, req *http.Request) {
+	log.Printf("[DEBUG] Read Cache: %s for %s %s (hits: %d, misses: %d, bypassed: %d)", result, req.Method, req.URL, t.hits, t.misses, t.bypassed)
+}
@@ -0,0 +1,272 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setUpReadCacheServerClient(hf http.Handler) (*httptest.Server, *http.Client, *readCacheTransport) {
+	ts := httptest.NewServer(hf)
+
+	client := ts.Client()
+	transport := NewTransportWithReadCache(client.Transport)
+	client.Transport = transport
+	return ts, client, transport
+}
+
+func testReadCacheSend(t *testing.T, client *http.Client, method, url string, cacheable bool) string {
+	ctx := context.Background()
+	if cacheable {
+		ctx = withReadCache(ctx)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response body: %v", err)
+	}
+	return string(body)
+}
+
+func TestExpandProviderReadCacheConfig(t *testing.T) {
+	config, err := ExpandProviderReadCacheConfig(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Enabled {
+		t.Errorf("expected read cache to be disabled by default")
+	}
+
+	config, err = ExpandProviderReadCacheConfig([]interface{}{
+		map[string]interface{}{
+			"enabled": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Enabled {
+		t.Errorf("expected read cache to be enabled")
+	}
+}
+
+func TestReadCacheTransport_cachesMarkedReads(t *testing.T) {
+	var requests int32
+	ts, client, transport := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Write([]byte(r.URL.Path + " " + string(rune('0'+n))))
+	}))
+	defer ts.Close()
+
+	first := testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/firewallPolicies/fp", true)
+	second := testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/firewallPolicies/fp", true)
+	if first != second {
+		t.Errorf("expected cached response %q, got %q", first, second)
+	}
+
+	// Reads that aren't marked as cacheable are always sent.
+	testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/firewallPolicies/fp", false)
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+	if transport.hits != 1 || transport.misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d hits and %d misses", transport.hits, transport.misses)
+	}
+}
+
+func TestReadCacheTransport_keyIncludesHeaders(t *testing.T) {
+	var requests int32
+	ts, client, _ := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(r.Header.Get("X-Goog-User-Project")))
+	}))
+	defer ts.Close()
+
+	for _, project := range []string{"a", "b", "a"} {
+		req, err := http.NewRequestWithContext(withReadCache(context.Background()), "GET", ts.URL+"/projects/p", nil)
+		if err != nil {
+			t.Fatalf("unable to construct request: %v", err)
+		}
+		req.Header.Set("X-Goog-User-Project", project)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != project {
+			t.Errorf("expected response for project %q, got %q", project, body)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestReadCacheTransport_doesNotCacheErrors(t *testing.T) {
+	var requests int32
+	ts, client, _ := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("found"))
+	}))
+	defer ts.Close()
+
+	testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/networks/n", true)
+	if body := testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/networks/n", true); body != "found" {
+		t.Errorf("expected the read to be sent again after an error, got %q", body)
+	}
+}
+
+func TestReadCacheTransport_deduplicatesConcurrentReads(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	ts, client, _ := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte("rules"))
+	}))
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bodies[i] = testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/firewallPolicies/fp", true)
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+	for _, body := range bodies {
+		if body != "rules" {
+			t.Errorf("expected every read to get the shared response, got %q", body)
+		}
+	}
+}
+
+func TestReadCacheTransport_canceledReadIsNotShared(t *testing.T) {
+	var requests int32
+	ts, client, _ := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// Hang the first request until its caller gives up
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("rules"))
+	}))
+	defer ts.Close()
+	url := ts.URL + "/projects/p/firewallPolicies/fp"
+
+	ctx, cancel := context.WithCancel(withReadCache(context.Background()))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	firstErr := make(chan error)
+	go func() {
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		firstErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	secondReq, err := http.NewRequestWithContext(withReadCache(context.Background()), "GET", url, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	secondErr := make(chan error)
+	go func() {
+		resp, err := client.Do(secondReq)
+		if err == nil {
+			resp.Body.Close()
+		}
+		secondErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; err == nil {
+		t.Errorf("expected the canceled read to fail")
+	}
+	if err := <-secondErr; err != nil {
+		t.Errorf("expected the second read to succeed, got %v", err)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestReadCacheTransport_mutationsInvalidateRelatedReads(t *testing.T) {
+	var requests int32
+	ts, client, transport := setUpReadCacheServerClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(r.Method))
+	}))
+	defer ts.Close()
+
+	policy := ts.URL + "/projects/p/firewallPolicies/fp"
+	network := ts.URL + "/projects/p/networks/n"
+	testReadCacheSend(t, client, "GET", policy, true)
+	testReadCacheSend(t, client, "GET", network, true)
+	testReadCacheSend(t, client, "GET", ts.URL+"/projects/p/firewallPolicies", true)
+
+	// A custom method on the policy evicts the policy and its collection, but not the network.
+	testReadCacheSend(t, client, "POST", policy+":addRule", false)
+	if len(transport.entries) != 1 {
+		t.Fatalf("expected only the network read to stay cached, got %d entries", len(transport.entries))
+	}
+
+	// Reads of the mutated policy are sent every time from now on.
+	before := atomic.LoadInt32(&requests)
+	testReadCacheSend(t, client, "GET", policy, true)
+	testReadCacheSend(t, client, "GET", policy, true)
+	testReadCacheSend(t, client, "GET", network, true)
+	if sent := atomic.LoadInt32(&requests) - before; sent != 2 {
+		t.Errorf("expected 2 requests, got %d", sent)
+	}
+	if transport.bypassed != 2 {
+		t.Errorf("expected 2 bypassed reads, got %d", transport.bypassed)
+	}
+}
+
+func TestMutatedResource(t *testing.T) {
+	cases := map[string]string{
+		"https://compute.googleapis.com/compute/v1/projects/p/global/firewallPolicies/fp/addRule": "compute.googleapis.com/compute/v1/projects/p/global/firewallPolicies/fp/addRule",
+		"https://example.googleapis.com/v1/projects/p/locations/l/things:batchCreate":             "example.googleapis.com/v1/projects/p/locations/l/things",
+		"https://example.googleapis.com/v1/projects/example.com:p/things/t?updateMask=a":          "example.googleapis.com/v1/projects/example.com:p/things/t",
+	}
+	for url, expected := range cases {
+		req, err := http.NewRequest("POST", url, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatalf("unable to construct request: %v", err)
+		}
+		if got := mutatedResource(req); got != expected {
+			t.Errorf("expected %q for %s, got %q", expected, url, got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const DefaultRetryInitialBackoff = 500 * time.Millisecond
+
+// RetryPolicy controls how the retry transport retries requests that fail
+// with a temporary error. It is configured by the provider-level `retry` block.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a request, including
+	// the first one. Zero means requests are retried until Timeout.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. Later waits grow
+	// following the Fibonacci sequence.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait by up to this fraction, between 0 and 1.
+	Jitter float64
+	// Timeout is the total time spent retrying a single request.
+	Timeout time.Duration
+	// RetryableCodes are HTTP status codes retried in addition to the defaults.
+	RetryableCodes []int
+	// RetryablePatterns are matched against error messages, and matching
+	// errors are retried in addition to the defaults.
+	RetryablePatterns []*regexp.Regexp
+}
+
+// DefaultRetryPolicy returns the policy used when the provider has no `retry` block.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialBackoff: DefaultRetryInitialBackoff,
+		Timeout:        defaultRetryTransportTimeoutSec * time.Second,
+	}
+}
+
+func ExpandProviderRetryPolicy(v interface{}) (*RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+
+	if v == nil {
+		return policy, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return policy, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if maxAttempts, ok := cfgV["max_attempts"]; ok {
+		policy.MaxAttempts = maxAttempts.(int)
+	}
+
+	for key, dest := range map[string]*time.Duration{
+		"initial_backoff": &policy.InitialBackoff,
+		"max_backoff":     &policy.MaxBackoff,
+		"timeout":         &policy.Timeout,
+	} {
+		durV, ok := cfgV[key]
+		if !ok || durV == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(durV.(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse duration from '%s' value %q", key, durV)
+		}
+		// A zero max_backoff means no cap, but a zero initial_backoff or
+		// timeout would retry in a busy loop or not at all.
+		if dur < 0 || (dur == 0 && key != "max_backoff") {
+			return nil, fmt.Errorf("'%s' value %q must be greater than zero", key, durV)
+		}
+		*dest = dur
+	}
+	if policy.MaxBackoff != 0 && policy.MaxBackoff < policy.InitialBackoff {
+		return nil, fmt.Errorf("'max_backoff' %s must not be less than 'initial_backoff' %s", policy.MaxBackoff, policy.InitialBackoff)
+	}
+
+	if jitter, ok := cfgV["jitter"]; ok {
+		policy.Jitter = jitter.(float64)
+	}
+
+	if codes, ok := cfgV["retryable_status_codes"]; ok {
+		for _, code := range codes.([]interface{}) {
+			policy.RetryableCodes = append(policy.RetryableCodes, code.(int))
+		}
+	}
+
+	if patterns, ok := cfgV["retryable_error_patterns"]; ok {
+		for _, pattern := range patterns.([]interface{}) {
+			re, err := regexp.Compile(pattern.(string))
+			if err != nil {
+				return nil, fmt.Errorf("unable to compile 'retryable_error_patterns' value %q: %s", pattern, err)
+			}
+			policy.RetryablePatterns = append(policy.RetryablePatterns, re)
+		}
+	}
+
+	return policy, nil
+}
+
+func (p *RetryPolicy) String() string {
+	patterns := make([]string, 0, len(p.RetryablePatterns))
+	for _, re := range p.RetryablePatterns {
+		patterns = append(patterns, strconv.Quote(re.String()))
+	}
+	return fmt.Sprintf("max_attempts=%d initial_backoff=%s max_backoff=%s jitter=%g timeout=%s retryable_status_codes=%v retryable_error_patterns=[%s]",
+		p.MaxAttempts, p.InitialBackoff, p.MaxBackoff, p.Jitter, p.Timeout, p.RetryableCodes, strings.Join(patterns, " "))
+}
+
+// errorRetryPredicates returns predicates for the additional status codes
+// and error message patterns configured on the policy.
+func (p *RetryPolicy) errorRetryPredicates() []RetryErrorPredicateFunc {
+	var predicates []RetryErrorPredicateFunc
+	if len(p.RetryableCodes) > 0 {
+		codes := p.RetryableCodes
+		predicates = append(predicates, func(err error) (bool, string) {
+			var gerr *googleapi.Error
+			if !errors.As(err, &gerr) {
+				return false, ""
+			}
+			for _, code := range codes {
+				if gerr.Code == code {
+					return true, fmt.Sprintf("Retryable error code %d configured in the provider retry policy", code)
+				}
+			}
+			return false, ""
+		})
+	}
+	if len(p.RetryablePatterns) > 0 {
+		patterns := p.RetryablePatterns
+		predicates = append(predicates, func(err error) (bool, string) {
+			for _, re := range patterns {
+				if re.MatchString(err.Error()) {
+					return true, fmt.Sprintf("Error matches pattern %q configured in the provider retry policy", re)
+				}
+			}
+			return false, ""
+		})
+	}
+	return predicates
+}
+
+// nextBackoff returns the wait that follows backoff and lastBackoff in the
+// Fibonacci sequence, capped at MaxBackoff.
+func (p *RetryPolicy) nextBackoff(backoff, lastBackoff time.Duration) time.Duration {
+	next := backoff + lastBackoff
+	if p.MaxBackoff > 0 && next > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return next
+}
+
+// wait returns how long to wait before retrying. It randomizes backoff by
+// the policy's Jitter, and waits at least as long as the server asked for
+// in a Retry-After header.
+func (p *RetryPolicy) wait(backoff time.Duration, resp *http.Response) time.Duration {
+	wait := backoff
+	if p.Jitter > 0 {
+		wait += time.Duration(p.Jitter * (2*rand.Float64() - 1) * float64(backoff))
+	}
+	if retryAfter, ok := retryAfterDelay(resp, time.Now()); ok && retryAfter > wait {
+		wait = retryAfter
+	}
+	return wait
+}
+
+// retryAfterDelay parses the Retry-After header of a 429 or 503 response,
+// which is either a number of seconds or an HTTP date.
+func retryAfterDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
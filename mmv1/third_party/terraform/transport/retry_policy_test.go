@@ -0,0 +1,214 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestExpandProviderRetryPolicy_defaults(t *testing.T) {
+	for _, v := range []interface{}{nil, []interface{}{}, []interface{}{nil}} {
+		policy, err := ExpandProviderRetryPolicy(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policy.InitialBackoff != DefaultRetryInitialBackoff {
+			t.Errorf("expected InitialBackoff %s, got %s", DefaultRetryInitialBackoff, policy.InitialBackoff)
+		}
+		if policy.Timeout != defaultRetryTransportTimeoutSec*time.Second {
+			t.Errorf("expected Timeout %ds, got %s", defaultRetryTransportTimeoutSec, policy.Timeout)
+		}
+		if policy.MaxAttempts != 0 || policy.MaxBackoff != 0 || policy.Jitter != 0 {
+			t.Errorf("expected no limits by default, got %s", policy)
+		}
+	}
+}
+
+func TestExpandProviderRetryPolicy_custom(t *testing.T) {
+	policy, err := ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"max_attempts":             5,
+			"initial_backoff":          "1s",
+			"max_backoff":              "30s",
+			"jitter":                   0.2,
+			"timeout":                  "10m",
+			"retryable_status_codes":   []interface{}{409, 412},
+			"retryable_error_patterns": []interface{}{"try again later"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `max_attempts=5 initial_backoff=1s max_backoff=30s jitter=0.2 timeout=10m0s retryable_status_codes=[409 412] retryable_error_patterns=["try again later"]`
+	if got := policy.String(); got != expected {
+		t.Errorf("expected policy %s, got %s", expected, got)
+	}
+}
+
+func TestExpandProviderRetryPolicy_errors(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"invalid duration": {
+			"initial_backoff": "soon",
+		},
+		"zero initial backoff": {
+			"initial_backoff": "0s",
+		},
+		"zero timeout": {
+			"timeout": "0s",
+		},
+		"negative max backoff": {
+			"max_backoff": "-1s",
+		},
+		"max backoff less than initial backoff": {
+			"initial_backoff": "10s",
+			"max_backoff":     "1s",
+		},
+		"invalid pattern": {
+			"retryable_error_patterns": []interface{}{"("},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if _, err := ExpandProviderRetryPolicy([]interface{}{tc}); err == nil {
+				t.Fatalf("expected error for %v", tc)
+			}
+		})
+	}
+}
+
+func TestExpandProviderRetryPolicy_zeroMaxBackoff(t *testing.T) {
+	policy, err := ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"max_backoff": "0s",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.MaxBackoff != 0 {
+		t.Errorf("expected no MaxBackoff, got %s", policy.MaxBackoff)
+	}
+}
+
+func TestRetryPolicy_errorRetryPredicates(t *testing.T) {
+	policy, err := ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"retryable_status_codes":   []interface{}{409},
+			"retryable_error_patterns": []interface{}{"(?i)try again"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		err       error
+		retryable bool
+	}{
+		"configured code": {
+			err:       &googleapi.Error{Code: 409, Message: "conflict"},
+			retryable: true,
+		},
+		"configured code wrapped": {
+			err:       fmt.Errorf("wrapped: %w", &googleapi.Error{Code: 409}),
+			retryable: true,
+		},
+		"matching message": {
+			err:       errors.New("Please TRY AGAIN in a minute"),
+			retryable: true,
+		},
+		"other error": {
+			err:       &googleapi.Error{Code: 400, Message: "bad request"},
+			retryable: false,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := IsRetryableError(tc.err, policy.errorRetryPredicates(), nil); got != tc.retryable {
+				t.Errorf("expected retryable %t, got %t", tc.retryable, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_nextBackoff(t *testing.T) {
+	policy := &RetryPolicy{MaxBackoff: 2 * time.Second}
+
+	backoff, last := 500*time.Millisecond, 500*time.Millisecond
+	var got []time.Duration
+	for i := 0; i < 5; i++ {
+		backoff, last = policy.nextBackoff(backoff, last), backoff
+		got = append(got, backoff)
+	}
+
+	expected := []time.Duration{time.Second, 1500 * time.Millisecond, 2 * time.Second, 2 * time.Second, 2 * time.Second}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected backoffs %v, got %v", expected, got)
+	}
+}
+
+func TestRetryPolicy_waitJitter(t *testing.T) {
+	policy := &RetryPolicy{Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if wait := policy.wait(time.Second, nil); wait < 500*time.Millisecond || wait > 1500*time.Millisecond {
+			t.Fatalf("expected wait within 50%% of 1s, got %s", wait)
+		}
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		code       int
+		retryAfter string
+		expected   time.Duration
+		ok         bool
+	}{
+		"seconds on 429": {
+			code:       429,
+			retryAfter: "7",
+			expected:   7 * time.Second,
+			ok:         true,
+		},
+		"http date on 503": {
+			code:       503,
+			retryAfter: now.Add(time.Minute).Format(http.TimeFormat),
+			expected:   time.Minute,
+			ok:         true,
+		},
+		"date in the past": {
+			code:       503,
+			retryAfter: now.Add(-time.Minute).Format(http.TimeFormat),
+			expected:   0,
+			ok:         true,
+		},
+		"ignored on 500": {
+			code:       500,
+			retryAfter: "7",
+		},
+		"missing header": {
+			code: 429,
+		},
+		"invalid header": {
+			code:       429,
+			retryAfter: "later",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tc.code, Header: http.Header{}}
+			if tc.retryAfter != "" {
+				resp.Header.Set("Retry-After", tc.retryAfter)
+			}
+			got, ok := retryAfterDelay(resp, now)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("expected (%s, %t), got (%s, %t)", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+}
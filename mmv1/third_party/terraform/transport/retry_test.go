@@ -1,6 +1,9 @@
 package transport
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -210,3 +213,55 @@ func TestRetryWithPolling_retriedAndFailed(t *testing.T) {
 		t.Errorf("expected error function to be called exactly twice, but was called %d times", retryCount)
 	}
 }
+
+func TestRetry_contextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Retry(RetryOptions{
+		Context: ctx,
+		RetryFunc: func() error {
+			return &googleapi.Error{
+				Code: 500,
+			}
+		},
+		Timeout: 1 * time.Minute,
+	})
+	if err == nil {
+		t.Errorf("expected an error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected retries to stop when the context is done, but they ran for %s", elapsed)
+	}
+}
+
+func TestSendRequest_contextCancelled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the request is cancelled
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := SendRequest(SendRequestOptions{
+		Context:   ctx,
+		Config:    &Config{Client: ts.Client()},
+		Method:    "GET",
+		RawURL:    ts.URL,
+		UserAgent: "test",
+		Timeout:   1 * time.Minute,
+	})
+	if err == nil {
+		t.Errorf("expected an error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the request to be cancelled with its context, but it ran for %s", elapsed)
+	}
+}
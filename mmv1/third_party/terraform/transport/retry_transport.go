@@ -54,6 +54,21 @@ func NewTransportWithDefaultRetries(t http.RoundTripper) *retryTransport {
 	}
 }
 
+// NewTransportWithRetryPolicy constructs a retryTransport that retries common
+// temporary errors, and any errors the policy adds, using the policy's backoff.
+// A nil policy uses DefaultRetryPolicy.
+func NewTransportWithRetryPolicy(t http.RoundTripper, policy *RetryPolicy) *retryTransport {
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	predicates := append([]RetryErrorPredicateFunc{}, defaultErrorRetryPredicates...)
+	return &retryTransport{
+		retryPredicates: append(predicates, policy.errorRetryPredicates()...),
+		internal:        t,
+		policy:          policy,
+	}
+}
+
 // Helper method to create a shallow copy of an HTTP client with a shallow-copied retryTransport
 // s.t. the base HTTP transport is the same (i.e. client connection pools are shared, retryPredicates are different)
 func ClientWithAdditionalRetries(baseClient *http.Client, predicates ...RetryErrorPredicateFunc) *http.Client {
@@ -74,17 +89,25 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
 	internal        http.RoundTripper
+	// policy controls backoff and the number of attempts. A nil policy
+	// uses DefaultRetryPolicy.
+	policy *RetryPolicy
 }
 
 // RoundTrip implements the RoundTripper interface method.
 // It retries the given HTTP request based on the retry predicates
 // registered under the retryTransport.
 func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, respErr error) {
-	// Set timeout to default value.
+	policy := t.policy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	// Set timeout to the policy value.
 	ctx := req.Context()
 	var ccancel context.CancelFunc
 	if _, ok := ctx.Deadline(); !ok {
-		ctx, ccancel = context.WithTimeout(ctx, defaultRetryTransportTimeoutSec*time.Second)
+		ctx, ccancel = context.WithTimeout(ctx, policy.Timeout)
 		defer func() {
 			if ctx.Err() == nil {
 				// Cleanup child context created for retry loop if ctx not done.
@@ -94,8 +117,8 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 	}
 
 	attempts := 0
-	backoff := time.Millisecond * 500
-	nextBackoff := time.Millisecond * 500
+	backoff := policy.InitialBackoff
+	nextBackoff := policy.InitialBackoff
 
 	// VCR depends on the original request body being consumed, so
 	// consume here. Since this won't affect the request itself,
@@ -134,17 +157,32 @@ Retry:
 			break Retry
 		}
 
-		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, reached max attempts %d", policy.MaxAttempts)
+			break Retry
+		}
+
+		wait := policy.wait(backoff, resp)
+		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", wait)
 		select {
 		case <-ctx.Done():
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, context done: %v", ctx.Err())
+			// A request cancelled by the caller fails with the cancellation
+			// rather than the last retryable error. Timeouts still return the
+			// last error, which explains why the request kept failing.
+			if err := req.Context().Err(); errors.Is(err, context.Canceled) {
+				if resp != nil {
+					googleapi.CloseBody(resp)
+				}
+				resp, respErr = nil, err
+			}
 			break Retry
-		case <-time.After(backoff):
-			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
+		case <-time.After(wait):
+			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", wait)
 
 			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
 			lastBackoff := backoff
-			backoff = backoff + nextBackoff
+			backoff = policy.nextBackoff(backoff, nextBackoff)
 			nextBackoff = lastBackoff
 			continue
 		}
@@ -3,6 +3,7 @@ package transport
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -104,6 +105,26 @@ func TestRetryTransport_ContextTimeout(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+// Check for the cancellation error if the request is cancelled while retrying
+func TestRetryTransport_ContextCanceled(t *testing.T) {
+	ts, client := setUpRetryTransportServerClient(
+		// Request would succeed after the request is cancelled
+		testRetryTransportHandler_returnAfter(t, time.Second*4, testRetryTransportCodeSuccess))
+	defer ts.Close()
+
+	ctx, cc := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second*1, cc)
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct err: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled error, got response %v and error %v", resp, err)
+	}
+}
+
 // Check for no errors if the request succeeds after a certain amount of time
 func TestRetryTransport_SuccessWithBody(t *testing.T) {
 	ts, client := setUpRetryTransportServerClient(
@@ -169,6 +190,83 @@ func TestRetryTransport_DoesNotRetryEmptyGetBody(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+// Check that retries stop after the policy's max attempts
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	attempts := 0
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(testRetryTransportCodeRetry)
+		}))
+	defer ts.Close()
+	client.Transport.(*retryTransport).policy = &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Minute,
+	}
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// Check that the transport waits as long as a 429 response's Retry-After header asks
+func TestRetryTransport_RetryAfter(t *testing.T) {
+	var attemptTimes []time.Time
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attemptTimes = append(attemptTimes, time.Now())
+			if len(attemptTimes) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+	client.Transport = NewTransportWithRetryPolicy(http.DefaultTransport, &RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Minute,
+	})
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if len(attemptTimes) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attemptTimes))
+	}
+	if wait := attemptTimes[1].Sub(attemptTimes[0]); wait < time.Second {
+		t.Errorf("expected to wait at least 1s before retrying, waited %s", wait)
+	}
+}
+
+// Check that status codes added by the policy are retried
+func TestRetryTransport_PolicyRetryableStatusCodes(t *testing.T) {
+	attempts := 0
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+	client.Transport = NewTransportWithRetryPolicy(http.DefaultTransport, &RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Minute,
+		RetryableCodes: []int{http.StatusConflict},
+	})
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
 // handlers
 func testRetryTransportHandler_noRetries(t *testing.T, code int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
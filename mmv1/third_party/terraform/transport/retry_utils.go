@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 )
 
 type RetryOptions struct {
+	// Context stops the retries when it is done. context.Background() is used
+	// when it is nil.
+	Context              context.Context
 	RetryFunc            func() error
 	Timeout              time.Duration
 	PollInterval         time.Duration
@@ -20,6 +24,10 @@ func Retry(opt RetryOptions) error {
 	if opt.Timeout == 0 {
 		opt.Timeout = 1 * time.Minute
 	}
+	ctx := opt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	if opt.PollInterval != 0 {
 		refreshFunc := func() (interface{}, string, error) {
@@ -48,11 +56,11 @@ func Retry(opt RetryOptions) error {
 			PollInterval: opt.PollInterval,
 		}
 
-		_, err := stateChange.WaitForState()
+		_, err := stateChange.WaitForStateContext(ctx)
 		return err
 	}
 
-	return retry.Retry(opt.Timeout, func() *retry.RetryError {
+	return retry.RetryContext(ctx, opt.Timeout, func() *retry.RetryError {
 		err := opt.RetryFunc()
 		if err == nil {
 			return nil
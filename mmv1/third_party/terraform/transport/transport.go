@@ -2,6 +2,7 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -17,6 +18,10 @@ import (
 var DefaultRequestTimeout = 5 * time.Minute
 
 type SendRequestOptions struct {
+	// Context cancels the request and the retries around it, for example when
+	// Terraform is interrupted or the timeout of the operation expires.
+	// context.Background() is used when it is nil.
+	Context              context.Context
 	Config               *Config
 	Method               string
 	Project              string
@@ -27,6 +32,10 @@ type SendRequestOptions struct {
 	Headers              http.Header
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// ReadCache allows a GET to be served from the provider's read cache when
+	// it is enabled. Set it only for reads whose response can be shared with
+	// other resources during a provider run.
+	ReadCache bool
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
@@ -57,8 +66,17 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		opt.Timeout = DefaultRequestTimeout
 	}
 
+	ctx := opt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opt.ReadCache {
+		ctx = withReadCache(ctx)
+	}
+
 	var res *http.Response
 	err := Retry(RetryOptions{
+		Context: ctx,
 		RetryFunc: func() error {
 			var buf bytes.Buffer
 			if opt.Body != nil {
@@ -72,7 +90,7 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			if err != nil {
 				return err
 			}
-			req, err := http.NewRequest(opt.Method, u, &buf)
+			req, err := http.NewRequestWithContext(ctx, opt.Method, u, &buf)
 			if err != nil {
 				return err
 			}
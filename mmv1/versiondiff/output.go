@@ -0,0 +1,87 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versiondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Writes the diffs in the given format: text or json.
+func Write(w io.Writer, format string, diffs []ResourceDiff) error {
+	switch format {
+	case "text":
+		return writeText(w, diffs)
+	case "json":
+		return writeJSON(w, diffs)
+	}
+	return fmt.Errorf("unknown version-diff output format %q, expected one of text, json", format)
+}
+
+// Writes one row per difference, as a table.
+func writeText(w io.Writer, diffs []ResourceDiff) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PRODUCT\tRESOURCE\tCHANGE\tFIELD\tDETAIL")
+	row := func(d ResourceDiff, change, field, detail string) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", d.Product, d.Resource, change, field, detail)
+	}
+
+	var betaOnlyResources, fieldDiffs int
+	for _, d := range diffs {
+		if d.BetaOnly {
+			betaOnlyResources++
+			row(d, "beta-only resource", "-", "")
+		}
+		for _, f := range d.BetaOnlyFields {
+			row(d, "beta-only field", f, "")
+		}
+		for _, f := range d.GaOnlyFields {
+			row(d, "ga-only field", f, "")
+		}
+		for _, e := range d.EnumDiffs {
+			var detail []string
+			if len(e.BetaOnlyValues) > 0 {
+				detail = append(detail, "beta only: "+strings.Join(e.BetaOnlyValues, ", "))
+			}
+			if len(e.GaOnlyValues) > 0 {
+				detail = append(detail, "ga only: "+strings.Join(e.GaOnlyValues, ", "))
+			}
+			row(d, "enum values", e.Field, strings.Join(detail, "; "))
+		}
+		for _, f := range d.UnreachableFields {
+			row(d, "unreachable field", f, "min_version is newer than the exact_version of a parent")
+		}
+		fieldDiffs += len(d.BetaOnlyFields) + len(d.GaOnlyFields) + len(d.EnumDiffs) + len(d.UnreachableFields)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d resource(s) differ: %d beta-only resource(s), %d field difference(s)\n", len(diffs), betaOnlyResources, fieldDiffs)
+	return err
+}
+
+func writeJSON(w io.Writer, diffs []ResourceDiff) error {
+	out := struct {
+		Resources []ResourceDiff `json:"resources"`
+	}{Resources: diffs}
+	if out.Resources == nil {
+		out.Resources = []ResourceDiff{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
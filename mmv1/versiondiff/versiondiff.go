@@ -0,0 +1,283 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versiondiff reports the differences between the ga and beta
+// providers: the resources and fields that only exist at one version, and the
+// enum values that differ between them.
+package versiondiff
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	ga   = "ga"
+	beta = "beta"
+)
+
+type Options struct {
+	// Product directories to compare, for example "products/pubsub"
+	Products []string
+}
+
+// The differences of one resource between ga and beta. Fields are dot
+// separated Terraform paths, e.g. `schema_settings.encoding`.
+type ResourceDiff struct {
+	Product string `json:"product"`
+	// The Terraform name, e.g. google_pubsub_topic
+	Resource string `json:"resource"`
+	File     string `json:"file"`
+
+	// The resource only exists in the beta provider
+	BetaOnly bool `json:"beta_only,omitempty"`
+
+	BetaOnlyFields []string   `json:"beta_only_fields,omitempty"`
+	GaOnlyFields   []string   `json:"ga_only_fields,omitempty"`
+	EnumDiffs      []EnumDiff `json:"enum_diffs,omitempty"`
+
+	// Fields with a min_version that is newer than the exact_version of one
+	// of their parents, which are therefore never generated
+	UnreachableFields []string `json:"unreachable_fields,omitempty"`
+}
+
+type EnumDiff struct {
+	Field          string   `json:"field"`
+	BetaOnlyValues []string `json:"beta_only_values,omitempty"`
+	GaOnlyValues   []string `json:"ga_only_values,omitempty"`
+}
+
+func (d ResourceDiff) empty() bool {
+	return !d.BetaOnly && len(d.BetaOnlyFields) == 0 && len(d.GaOnlyFields) == 0 && len(d.EnumDiffs) == 0 && len(d.UnreachableFields) == 0
+}
+
+// Loads every product at ga and at beta, and returns the resources that differ
+// between the two versions, sorted by product and resource.
+func Run(opts Options) ([]ResourceDiff, error) {
+	var diffs []ResourceDiff
+	for _, productDir := range opts.Products {
+		gaProduct, err := loadProduct(productDir, ga)
+		if err != nil {
+			return nil, err
+		}
+		betaProduct, err := loadProduct(productDir, beta)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, Compare(gaProduct, betaProduct)...)
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Product != diffs[j].Product {
+			return diffs[i].Product < diffs[j].Product
+		}
+		return diffs[i].Resource < diffs[j].Resource
+	})
+	return diffs, nil
+}
+
+// Loads a product and its resources as they are generated at version. Returns
+// nil if the product does not exist at version.
+func loadProduct(productDir, version string) (*api.Product, error) {
+	productApi := &api.Product{}
+	if err := api.Compile(filepath.Join(productDir, "product.yaml"), productApi, ""); err != nil {
+		return nil, err
+	}
+	if !productApi.ExistsAtVersionOrLower(version) {
+		return nil, nil
+	}
+
+	resourceFiles, err := filepath.Glob(filepath.Join(productDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceYamlPath := range resourceFiles {
+		if filepath.Base(resourceYamlPath) == "product.yaml" {
+			continue
+		}
+		resource := &api.Resource{}
+		if err := api.Compile(resourceYamlPath, resource, ""); err != nil {
+			return nil, err
+		}
+		resource.SourceYamlFile = resourceYamlPath
+		resource.TargetVersionName = version
+		resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil, "")
+		resource.SetDefault(productApi)
+		productApi.Objects = append(productApi.Objects, resource)
+	}
+
+	versionObj := productApi.VersionObjOrClosest(version)
+	for _, r := range productApi.Objects {
+		r.ExcludeIfNotInVersion(versionObj)
+	}
+	return productApi, nil
+}
+
+// Compares a product loaded at ga with the same product loaded at beta.
+// gaProduct is nil for products that only exist in beta.
+func Compare(gaProduct, betaProduct *api.Product) []ResourceDiff {
+	if betaProduct == nil {
+		return nil
+	}
+
+	var diffs []ResourceDiff
+	for _, r := range betaProduct.Objects {
+		if r.IsExcluded() {
+			continue
+		}
+		d := ResourceDiff{
+			Product:           betaProduct.Name,
+			Resource:          r.TerraformName(),
+			File:              r.SourceYamlFile,
+			UnreachableFields: unreachableFields(r),
+		}
+
+		gaResource := findResource(gaProduct, r.Name)
+		if gaResource == nil {
+			d.BetaOnly = true
+		} else {
+			gaFields := fields(gaResource)
+			betaFields := fields(r)
+			for _, path := range sortedKeys(betaFields) {
+				gaField, ok := gaFields[path]
+				if !ok {
+					d.BetaOnlyFields = appendField(d.BetaOnlyFields, path)
+					continue
+				}
+				if e := enumDiff(path, gaField, betaFields[path]); e != nil {
+					d.EnumDiffs = append(d.EnumDiffs, *e)
+				}
+			}
+			for _, path := range sortedKeys(gaFields) {
+				if _, ok := betaFields[path]; !ok {
+					d.GaOnlyFields = appendField(d.GaOnlyFields, path)
+				}
+			}
+		}
+
+		if !d.empty() {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// Returns the resource named name if it is generated for p.
+func findResource(p *api.Product, name string) *api.Resource {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Objects {
+		if r.Name == name && !r.IsExcluded() {
+			return r
+		}
+	}
+	return nil
+}
+
+// Returns the fields of a resource that exist at the version it was loaded
+// at, by path.
+func fields(r *api.Resource) map[string]*api.Type {
+	all := map[string]*api.Type{}
+	var walk func(props []*api.Type, prefix string)
+	walk = func(props []*api.Type, prefix string) {
+		for _, p := range props {
+			if p.Exclude {
+				continue
+			}
+			path := prefix + google.Underscore(p.Name)
+			all[path] = p
+			walk(p.NestedProperties(), path+".")
+		}
+	}
+	walk(google.Concat(r.AllUserProperties(), r.VirtualFields), "")
+	return all
+}
+
+// Appends path to the sorted paths, unless one of its parents is already
+// listed: the children of a field that only exists at one version are implied.
+func appendField(paths []string, path string) []string {
+	if len(paths) > 0 && strings.HasPrefix(path, paths[len(paths)-1]+".") {
+		return paths
+	}
+	return append(paths, path)
+}
+
+func enumDiff(path string, gaField, betaField *api.Type) *EnumDiff {
+	gaValues, betaValues := enumValues(gaField), enumValues(betaField)
+	d := EnumDiff{Field: path}
+	for _, v := range betaValues {
+		if !slices.Contains(gaValues, v) {
+			d.BetaOnlyValues = append(d.BetaOnlyValues, v)
+		}
+	}
+	for _, v := range gaValues {
+		if !slices.Contains(betaValues, v) {
+			d.GaOnlyValues = append(d.GaOnlyValues, v)
+		}
+	}
+	if len(d.BetaOnlyValues) == 0 && len(d.GaOnlyValues) == 0 {
+		return nil
+	}
+	return &d
+}
+
+func enumValues(t *api.Type) []string {
+	if t.IsA("Array") && t.ItemType != nil {
+		return t.ItemType.EnumValues
+	}
+	return t.EnumValues
+}
+
+// Returns the declared fields, at any version, whose min_version is newer
+// than the exact_version of one of their parents.
+func unreachableFields(r *api.Resource) []string {
+	var unreachable []string
+	var walk func(props []*api.Type, prefix, exactVersion string)
+	walk = func(props []*api.Type, prefix, exactVersion string) {
+		for _, p := range props {
+			path := prefix + google.Underscore(p.Name)
+			if exactVersion != "" && p.MinVersion != "" && slices.Index(product.ORDER, p.MinVersion) > slices.Index(product.ORDER, exactVersion) {
+				unreachable = append(unreachable, path)
+			}
+			childExactVersion := exactVersion
+			if p.ExactVersion != "" {
+				childExactVersion = p.ExactVersion
+			}
+			var children []*api.Type
+			if p.IsA("NestedObject") {
+				children = p.Properties
+			} else if p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject") {
+				children = p.ItemType.Properties
+			}
+			walk(children, path+".", childExactVersion)
+		}
+	}
+	walk(google.Concat(r.Properties, r.Parameters), "", "")
+	slices.Sort(unreachable)
+	return unreachable
+}
+
+func sortedKeys(m map[string]*api.Type) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
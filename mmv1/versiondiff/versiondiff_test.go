@@ -0,0 +1,143 @@
+package versiondiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	ga := &api.Product{
+		Name: "Pubsub",
+		Objects: []*api.Resource{
+			{
+				Name: "Topic",
+				Properties: []*api.Type{
+					{Name: "name", Type: "String"},
+					{Name: "encoding", Type: "Enum", EnumValues: []string{"JSON", "BINARY"}},
+					{Name: "removedField", Type: "String"},
+					{Name: "betaField", Type: "NestedObject", Exclude: true, Properties: []*api.Type{
+						{Name: "child", Type: "String"},
+					}},
+				},
+			},
+		},
+	}
+	beta := &api.Product{
+		Name: "Pubsub",
+		Objects: []*api.Resource{
+			{
+				Name: "Topic",
+				Properties: []*api.Type{
+					{Name: "name", Type: "String"},
+					{Name: "encoding", Type: "Enum", EnumValues: []string{"JSON", "AVRO"}},
+					{Name: "removedField", Type: "String", Exclude: true},
+					{Name: "betaField", Type: "NestedObject", Properties: []*api.Type{
+						{Name: "child", Type: "String"},
+					}},
+				},
+			},
+			{
+				Name: "Schema",
+				Properties: []*api.Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+		},
+	}
+
+	for _, p := range []*api.Product{ga, beta} {
+		for _, r := range p.Objects {
+			r.SetDefault(p)
+		}
+	}
+
+	expected := []ResourceDiff{
+		{
+			Product:        "Pubsub",
+			Resource:       "google_pubsub_topic",
+			BetaOnlyFields: []string{"beta_field"},
+			GaOnlyFields:   []string{"removed_field"},
+			EnumDiffs: []EnumDiff{
+				{Field: "encoding", BetaOnlyValues: []string{"AVRO"}, GaOnlyValues: []string{"BINARY"}},
+			},
+		},
+		{
+			Product:  "Pubsub",
+			Resource: "google_pubsub_schema",
+			BetaOnly: true,
+		},
+	}
+
+	if got := Compare(ga, beta); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+	if got := Compare(nil, beta); len(got) != 2 || !got[0].BetaOnly || !got[1].BetaOnly {
+		t.Errorf("expected every resource of a beta-only product to be beta-only, got %+v", got)
+	}
+}
+
+func TestUnreachableFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		properties  []*api.Type
+		expected    []string
+	}{
+		{
+			description: "beta field in a ga-only object",
+			properties: []*api.Type{
+				{Name: "config", Type: "NestedObject", ExactVersion: "ga", Properties: []*api.Type{
+					{Name: "gaField", Type: "String"},
+					{Name: "betaField", Type: "String", MinVersion: "beta"},
+				}},
+			},
+			expected: []string{"config.beta_field"},
+		},
+		{
+			description: "beta field in an array of a ga-only object",
+			properties: []*api.Type{
+				{Name: "config", Type: "NestedObject", ExactVersion: "ga", Properties: []*api.Type{
+					{Name: "items", Type: "Array", ItemType: &api.Type{Type: "NestedObject", Properties: []*api.Type{
+						{Name: "betaField", Type: "String", MinVersion: "beta"},
+					}}},
+				}},
+			},
+			expected: []string{"config.items.beta_field"},
+		},
+		{
+			description: "beta field in a beta-only object",
+			properties: []*api.Type{
+				{Name: "config", Type: "NestedObject", ExactVersion: "beta", Properties: []*api.Type{
+					{Name: "betaField", Type: "String", MinVersion: "beta"},
+				}},
+			},
+		},
+		{
+			description: "beta field in an object at every version",
+			properties: []*api.Type{
+				{Name: "config", Type: "NestedObject", Properties: []*api.Type{
+					{Name: "betaField", Type: "String", MinVersion: "beta"},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := &api.Resource{Name: "Topic", Properties: tc.properties}
+			r.SetDefault(&api.Product{Name: "Pubsub"})
+			if got := unreachableFields(r); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}